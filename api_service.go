@@ -24,7 +24,9 @@ func (r requestError) Error() string {
 }
 
 type requestErrorValue struct {
-	Message string `json:"localizedMessage"`
+	Message    string `json:"localizedMessage"`
+	W3CMessage string `json:"message"`
+	ErrorCode  string `json:"error"`
 }
 
 type seleniumAPIService struct{}
@@ -53,6 +55,14 @@ func (a seleniumAPIService) performRequest(url string, method string, body io.Re
 
 		err := json.Unmarshal(r, &reqErr)
 		if err == nil {
+			// W3C compliant remote ends return the error code and message
+			// within the value rather than as the state.
+			if reqErr.State == "" {
+				reqErr.State = reqErr.Value.ErrorCode
+			}
+			if reqErr.Value.Message == "" {
+				reqErr.Value.Message = reqErr.Value.W3CMessage
+			}
 			return nil, &reqErr
 		}
 
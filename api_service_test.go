@@ -0,0 +1,36 @@
+package goselenium
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_APIService_LegacyErrorsAreParsed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"state": "no such element", "value": {"localizedMessage": "not found"}}`)
+	}))
+	defer server.Close()
+
+	_, err := seleniumAPIService{}.performRequest(server.URL, "GET", nil)
+	reqErr, ok := err.(*requestError)
+	if !ok || reqErr.State != NoSuchElement || reqErr.Value.Message != "not found" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_APIService_W3CErrorsAreParsed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"value": {"error": "unknown command", "message": "not supported", "stacktrace": ""}}`)
+	}))
+	defer server.Close()
+
+	_, err := seleniumAPIService{}.performRequest(server.URL, "POST", nil)
+	comErr := newCommunicationError(err, "Test", server.URL, nil)
+	if comErr.Response.State != UnknownCommand || comErr.Response.Message != "not supported" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
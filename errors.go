@@ -1,6 +1,9 @@
 package goselenium
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // ErrorResponse is what is returned from the Selenium API when an error
 // occurs.
@@ -47,6 +50,20 @@ func newCommunicationError(err error, method string, url string, resp []byte) Co
 	}
 }
 
+// newNoSuchElementError creates a communication error for elements that were
+// searched for on the client, so that it can be handled in the same way as a
+// 'no such element' error returned from the remote end.
+func newNoSuchElementError(method string, url string, by By) CommunicationError {
+	return CommunicationError{
+		url: url,
+		Response: &ErrorResponse{
+			Message: fmt.Sprintf("unable to locate element using %s: %v", by.Type(), by.Value()),
+			State:   NoSuchElement,
+		},
+		method: method,
+	}
+}
+
 // UnmarshallingError is the result of an unmarshalling failure of a JSON
 // string.
 type UnmarshallingError struct {
@@ -142,3 +159,209 @@ func IsInvalidURLError(err error) bool {
 func newInvalidURLError(url string) InvalidURLError {
 	return InvalidURLError(url)
 }
+
+// UnexpectedTagNameError is an error that is returned when an element is not
+// of the type that was expected (i.e. a Select was created from an element
+// that is not a <select>).
+type UnexpectedTagNameError struct {
+	Expected string
+	Actual   string
+	method   string
+}
+
+// Error returns a formatted unexpected tag name error string.
+func (u UnexpectedTagNameError) Error() string {
+	return fmt.Sprintf("%s: expected element of type <%s> but was <%s>", u.method, u.Expected, u.Actual)
+}
+
+// IsUnexpectedTagNameError checks whether an error is due to an element being
+// of an unexpected type.
+func IsUnexpectedTagNameError(err error) bool {
+	_, ok := err.(UnexpectedTagNameError)
+	return ok
+}
+
+func newUnexpectedTagNameError(method string, expected string, actual string) UnexpectedTagNameError {
+	return UnexpectedTagNameError{
+		Expected: expected,
+		Actual:   actual,
+		method:   method,
+	}
+}
+
+// ElementClickInterceptedError is returned from Click when robust clicking is
+// enabled and the element is still covered by another element (such as a
+// sticky header or an overlay) after every attempt. Obscurer describes the
+// element that would have received the click, if it is known.
+type ElementClickInterceptedError struct {
+	ElementID string
+	Obscurer  string
+	Attempts  int
+	method    string
+}
+
+// Error returns a formatted element click intercepted error string.
+func (e ElementClickInterceptedError) Error() string {
+	return fmt.Sprintf("%s: element %s is obscured by %s after %d attempts", e.method, e.ElementID, e.Obscurer, e.Attempts)
+}
+
+// IsElementClickInterceptedError checks whether an error is due to an element
+// being obscured whilst robustly clicking it.
+func IsElementClickInterceptedError(err error) bool {
+	_, ok := err.(ElementClickInterceptedError)
+	return ok
+}
+
+func newElementClickInterceptedError(method string, id string, obscurer string, attempts int) ElementClickInterceptedError {
+	if obscurer == "" {
+		obscurer = "an unknown element"
+	}
+
+	return ElementClickInterceptedError{
+		ElementID: id,
+		Obscurer:  obscurer,
+		Attempts:  attempts,
+		method:    method,
+	}
+}
+
+// JavascriptExecutionError is returned from Eval when the script throws an
+// exception (or returns a Promise which is rejected). Name, Message and Stack
+// are those of the JavaScript error; Stack is empty if the browser did not
+// provide one or if the value thrown was not an Error.
+type JavascriptExecutionError struct {
+	Name    string
+	Message string
+	Stack   string
+	method  string
+}
+
+// Error returns a formatted JavaScript execution error string.
+func (j JavascriptExecutionError) Error() string {
+	err := fmt.Sprintf("%s: javascript error: %s: %s", j.method, j.Name, j.Message)
+	if j.Stack != "" {
+		err += "\n" + j.Stack
+	}
+
+	return err
+}
+
+// IsJavascriptExecutionError checks whether an error is due to a script
+// throwing an exception.
+func IsJavascriptExecutionError(err error) bool {
+	_, ok := err.(JavascriptExecutionError)
+	return ok
+}
+
+func newJavascriptExecutionError(method string, name string, message string, stack string) JavascriptExecutionError {
+	if name == "" {
+		name = "Error"
+	}
+
+	return JavascriptExecutionError{
+		Name:    name,
+		Message: message,
+		Stack:   stack,
+		method:  method,
+	}
+}
+
+// UnsupportedCommandError is returned when the remote end does not support a
+// command which has no fallback (i.e. MinimizeWindow on a driver which
+// predates the W3C specification). Err is the 'unknown command' or 'unknown
+// method' CommunicationError that the remote end returned.
+type UnsupportedCommandError struct {
+	Err    error
+	method string
+}
+
+// Error returns a formatted unsupported command error string.
+func (u UnsupportedCommandError) Error() string {
+	return fmt.Sprintf("%s: not supported by the remote end: %v", u.method, u.Err)
+}
+
+// Unwrap returns the error that the remote end returned.
+func (u UnsupportedCommandError) Unwrap() error {
+	return u.Err
+}
+
+// IsUnsupportedCommandError checks whether an error is due to the remote end
+// not supporting a command.
+func IsUnsupportedCommandError(err error) bool {
+	_, ok := err.(UnsupportedCommandError)
+	return ok
+}
+
+func newUnsupportedCommandError(method string, err error) UnsupportedCommandError {
+	return UnsupportedCommandError{
+		Err:    err,
+		method: method,
+	}
+}
+
+// WaitTimeoutError is returned when the context passed to a wait is done
+// before the condition being waited for is satisfied. Description describes
+// the condition, LastErr is the last error that checking the condition
+// returned (such as a 'no such element' CommunicationError) and LastValue is
+// the last value that a value condition observed, if any. Message is the
+// custom failure message of a FluentWait, if one was set.
+//
+// errors.Is and errors.As can be used to inspect both the error of the context
+// (i.e. context.DeadlineExceeded) and LastErr.
+type WaitTimeoutError struct {
+	Description string
+	Attempts    int
+	Elapsed     time.Duration
+	LastErr     error
+	LastValue   interface{}
+	Cause       error
+	Message     string
+	method      string
+}
+
+// Error returns a formatted wait timeout error string.
+func (w WaitTimeoutError) Error() string {
+	prefix := w.method
+	if w.Message != "" {
+		prefix += ": " + w.Message
+	}
+
+	err := fmt.Sprintf("%s: timed out waiting for %s after %d attempts (%s): %v", prefix, w.Description, w.Attempts, w.Elapsed, w.Cause)
+	if w.LastValue != nil {
+		err += fmt.Sprintf(", last value: %v", w.LastValue)
+	}
+	if w.LastErr != nil {
+		err += fmt.Sprintf(", last error: %v", w.LastErr)
+	}
+
+	return err
+}
+
+// Unwrap returns the error of the context and the last error returned whilst
+// checking the condition.
+func (w WaitTimeoutError) Unwrap() []error {
+	errs := []error{w.Cause}
+	if w.LastErr != nil {
+		errs = append(errs, w.LastErr)
+	}
+
+	return errs
+}
+
+// IsWaitTimeoutError checks whether an error is due to a wait timing out.
+func IsWaitTimeoutError(err error) bool {
+	_, ok := err.(WaitTimeoutError)
+	return ok
+}
+
+func newWaitTimeoutError(method string, description string, attempts int, elapsed time.Duration, lastErr error, lastValue interface{}, cause error) WaitTimeoutError {
+	return WaitTimeoutError{
+		Description: description,
+		Attempts:    attempts,
+		Elapsed:     elapsed,
+		LastErr:     lastErr,
+		LastValue:   lastValue,
+		Cause:       cause,
+		method:      method,
+	}
+}
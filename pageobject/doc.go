@@ -0,0 +1,32 @@
+// Package pageobject populates page objects from locators declared in struct
+// tags.
+//
+// A page object is a struct whose goselenium.Element and *pageobject.Elements
+// fields are tagged with the locator used to find them:
+//
+//	type LoginPage struct {
+//		Username goselenium.Element   `selenium:"id=username"`
+//		Password goselenium.Element   `selenium:"css=input[type=password]"`
+//		Errors   *pageobject.Elements `selenium:"class=error"`
+//		Banner   goselenium.Element   `selenium:"testid=banner,optional"`
+//		Header   HeaderComponent      `selenium:"css=header"`
+//	}
+//
+// Both are searched for the first time that they are used. Fields of type
+// []goselenium.Element are not supported, as a slice cannot be resolved
+// lazily.
+//
+// The tag is made up of a strategy and a value separated by an equals sign.
+// The supported strategies are css, xpath, id, name, class, tag, link,
+// partiallink, text, partialtext, label, placeholder, testid and role (where
+// the value is the role optionally followed by a colon and the accessible
+// name, i.e. role=button:Sign in). Any strategy registered with
+// goselenium.RegisterLocatorStrategy can also be used.
+//
+// Struct fields which are tagged are components; their fields are searched
+// for within the element found by the component's tag. Untagged struct fields
+// are searched for within the same scope as their parent.
+//
+// Elements are required unless their tag ends with ",optional"; Validate
+// returns an error listing every required element that cannot be found.
+package pageobject
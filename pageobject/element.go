@@ -0,0 +1,238 @@
+package pageobject
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bunsenapp/go-selenium"
+)
+
+// lazyElement is a goselenium.Element which is searched for the first time
+// that it is used.
+type lazyElement struct {
+	scope searchContext
+	by    goselenium.By
+
+	mu sync.Mutex
+	el goselenium.Element
+}
+
+func (l *lazyElement) resolve() (goselenium.Element, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.el != nil {
+		return l.el, nil
+	}
+
+	el, err := l.scope.FindElement(l.by)
+	if err != nil {
+		return nil, err
+	}
+
+	l.el = el
+	return el, nil
+}
+
+func (l *lazyElement) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.el = nil
+}
+
+// ID returns the ID of the element, or an empty string if it cannot be found.
+func (l *lazyElement) ID() string {
+	el, err := l.resolve()
+	if err != nil {
+		return ""
+	}
+
+	return el.ID()
+}
+
+func (l *lazyElement) Selected() (*goselenium.ElementSelectedResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.Selected()
+}
+
+func (l *lazyElement) Attribute(att string) (*goselenium.ElementAttributeResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.Attribute(att)
+}
+
+func (l *lazyElement) CSSValue(prop string) (*goselenium.ElementCSSValueResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.CSSValue(prop)
+}
+
+func (l *lazyElement) Text() (*goselenium.ElementTextResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.Text()
+}
+
+func (l *lazyElement) TagName() (*goselenium.ElementTagNameResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.TagName()
+}
+
+func (l *lazyElement) Rectangle() (*goselenium.ElementRectangleResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.Rectangle()
+}
+
+func (l *lazyElement) Enabled() (*goselenium.ElementEnabledResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.Enabled()
+}
+
+func (l *lazyElement) Click() (*goselenium.ElementClickResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.Click()
+}
+
+func (l *lazyElement) ScrollIntoView() (*goselenium.ElementScrollIntoViewResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.ScrollIntoView()
+}
+
+func (l *lazyElement) Clear() (*goselenium.ElementClearResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.Clear()
+}
+
+func (l *lazyElement) SendKeys(keys string) (*goselenium.ElementSendKeysResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.SendKeys(keys)
+}
+
+func (l *lazyElement) SendKeysWithDelay(keys string, delay time.Duration) (*goselenium.ElementSendKeysResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.SendKeysWithDelay(keys, delay)
+}
+
+func (l *lazyElement) FindElement(by goselenium.By) (goselenium.Element, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.FindElement(by)
+}
+
+func (l *lazyElement) FindElements(by goselenium.By) ([]goselenium.Element, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.FindElements(by)
+}
+
+func (l *lazyElement) Upload(paths ...string) (*goselenium.ElementUploadResponse, error) {
+	el, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return el.Upload(paths...)
+}
+
+// Elements is a collection of elements which is searched for the first time
+// that it is used. Page object fields of type *Elements are populated by Init
+// and are searched for again after Refresh.
+type Elements struct {
+	scope searchContext
+	by    goselenium.By
+
+	mu       sync.Mutex
+	elements []goselenium.Element
+	resolved bool
+}
+
+// All returns the elements, searching for them if they have not been
+// already. If there are no matching elements, an empty slice is returned.
+func (e *Elements) All() ([]goselenium.Element, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.resolved {
+		return e.elements, nil
+	}
+
+	elements, err := e.scope.FindElements(e.by)
+	if err != nil && !isNoSuchElement(err) {
+		return nil, err
+	}
+	if elements == nil {
+		elements = []goselenium.Element{}
+	}
+
+	e.elements, e.resolved = elements, true
+	return elements, nil
+}
+
+// Len returns the number of elements, or zero if they cannot be searched for.
+func (e *Elements) Len() int {
+	elements, err := e.All()
+	if err != nil {
+		return 0
+	}
+
+	return len(elements)
+}
+
+func (e *Elements) reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.elements, e.resolved = nil, false
+}
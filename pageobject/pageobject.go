@@ -0,0 +1,279 @@
+package pageobject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/bunsenapp/go-selenium"
+)
+
+// TagName is the name of the struct tag that locators are read from.
+const TagName = "selenium"
+
+var (
+	elementType  = reflect.TypeOf((*goselenium.Element)(nil)).Elem()
+	elementsType = reflect.TypeOf((*Elements)(nil))
+	sliceType    = reflect.TypeOf([]goselenium.Element(nil))
+)
+
+// searchContext is anything that elements can be searched for within; both
+// goselenium.WebDriver and goselenium.Element satisfy it.
+type searchContext interface {
+	FindElement(by goselenium.By) (goselenium.Element, error)
+	FindElements(by goselenium.By) ([]goselenium.Element, error)
+}
+
+// Page is a page object that has been populated by Init.
+type Page struct {
+	fields []*field
+}
+
+type field struct {
+	path     string
+	by       goselenium.By
+	optional bool
+	scope    searchContext
+	lazy     *lazyElement
+	elements *Elements
+	parent   *field
+}
+
+// Init populates the tagged fields of the struct that page points to.
+// goselenium.Element and *Elements fields are lazily resolved the first time
+// that they are used.
+//
+// An error is returned if page is not a pointer to a struct, or if any of its
+// tags are invalid or belong to fields of an unsupported type. This includes
+// []goselenium.Element, which cannot be resolved lazily; *Elements should be
+// used for collections instead.
+func Init(d goselenium.WebDriver, page interface{}) (*Page, error) {
+	if d == nil {
+		return nil, errors.New("pageobject: invalid driver argument")
+	}
+
+	v := reflect.ValueOf(page)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("pageobject: page must be a non-nil pointer to a struct")
+	}
+
+	p := &Page{}
+	err := p.bind(d, v.Elem(), v.Elem().Type().Name(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *Page) bind(scope searchContext, v reflect.Value, path string, parent *field) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+		fieldPath := path + "." + sf.Name
+
+		tag, tagged := sf.Tag.Lookup(TagName)
+		if !tagged {
+			if sf.PkgPath == "" && sf.Type.Kind() == reflect.Struct {
+				err := p.bind(scope, component(fv), fieldPath, parent)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if sf.PkgPath != "" {
+			return fmt.Errorf("pageobject: %s is unexported and cannot be populated", fieldPath)
+		}
+
+		by, optional, err := parseTag(tag)
+		if err != nil {
+			return fmt.Errorf("pageobject: %s: %s", fieldPath, err)
+		}
+
+		f := &field{path: fieldPath, by: by, optional: optional, scope: scope, parent: parent}
+		switch {
+		case sf.Type == elementType:
+			f.lazy = &lazyElement{scope: scope, by: by}
+			fv.Set(reflect.ValueOf(goselenium.Element(f.lazy)))
+		case sf.Type == elementsType:
+			f.elements = &Elements{scope: scope, by: by}
+			fv.Set(reflect.ValueOf(f.elements))
+		case sf.Type == sliceType:
+			return fmt.Errorf("pageobject: %s is a []goselenium.Element, which cannot be resolved lazily; use *pageobject.Elements instead", fieldPath)
+		case isComponent(sf.Type):
+			f.lazy = &lazyElement{scope: scope, by: by}
+			err := p.bind(f.lazy, component(fv), fieldPath, f)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("pageobject: %s has unsupported type %s", fieldPath, sf.Type)
+		}
+
+		p.fields = append(p.fields, f)
+	}
+
+	return nil
+}
+
+// Refresh discards any elements (and *Elements) that have already been
+// resolved so that they are searched for again. It should be called after
+// navigating or after the page has been re-rendered.
+func (p *Page) Refresh() error {
+	for _, f := range p.fields {
+		if f.lazy != nil {
+			f.lazy.reset()
+		}
+		if f.elements != nil {
+			f.elements.reset()
+		}
+	}
+
+	return nil
+}
+
+// Validate refreshes the page and then checks that every required element
+// can be found. If any cannot, a ValidationError listing them is returned.
+func (p *Page) Validate() error {
+	err := p.Refresh()
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, f := range p.fields {
+		if f.optional || f.withinMissingOptional() {
+			continue
+		}
+
+		if f.lazy != nil {
+			_, err := f.lazy.resolve()
+			if err != nil {
+				missing = append(missing, fmt.Sprintf("%s (%s=%v)", f.path, f.by.Type(), f.by.Value()))
+			}
+			continue
+		}
+
+		elements, err := f.elements.All()
+		if err != nil || len(elements) == 0 {
+			missing = append(missing, fmt.Sprintf("%s (%s=%v)", f.path, f.by.Type(), f.by.Value()))
+		}
+	}
+
+	if len(missing) > 0 {
+		return ValidationError{Missing: missing}
+	}
+
+	return nil
+}
+
+// withinMissingOptional returns whether the field belongs to an optional
+// component that cannot be found, in which case it is not required.
+func (f *field) withinMissingOptional() bool {
+	for c := f.parent; c != nil; c = c.parent {
+		if !c.optional {
+			continue
+		}
+		if _, err := c.lazy.resolve(); err != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidationError is returned from Validate when required elements cannot be
+// found. Missing contains the path of each field along with its locator.
+type ValidationError struct {
+	Missing []string
+}
+
+// Error returns a formatted validation error string.
+func (v ValidationError) Error() string {
+	return fmt.Sprintf("pageobject: required elements not found: %s", strings.Join(v.Missing, ", "))
+}
+
+// IsValidationError checks whether an error is a page object validation
+// error.
+func IsValidationError(err error) bool {
+	_, ok := err.(ValidationError)
+	return ok
+}
+
+func isComponent(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct || (t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct)
+}
+
+// component returns the struct value of a component field, allocating it if
+// the field is a nil pointer.
+func component(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return v.Elem()
+	}
+
+	return v
+}
+
+func isNoSuchElement(err error) bool {
+	comErr, ok := err.(goselenium.CommunicationError)
+	return ok && comErr.Response != nil && comErr.Response.State == goselenium.NoSuchElement
+}
+
+func parseTag(tag string) (goselenium.By, bool, error) {
+	optional := strings.HasSuffix(tag, ",optional")
+	tag = strings.TrimSuffix(tag, ",optional")
+
+	parts := strings.SplitN(tag, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, false, fmt.Errorf("invalid locator %q, expected strategy=value", tag)
+	}
+
+	strategy, value := parts[0], parts[1]
+	switch strategy {
+	case "css":
+		return goselenium.ByCSSSelector(value), optional, nil
+	case "xpath":
+		return goselenium.ByXPath(value), optional, nil
+	case "id":
+		return goselenium.ByID(value), optional, nil
+	case "name":
+		return goselenium.ByName(value), optional, nil
+	case "class":
+		return goselenium.ByClassName(value), optional, nil
+	case "tag":
+		return goselenium.ByTagName(value), optional, nil
+	case "link":
+		return goselenium.ByLinkText(value), optional, nil
+	case "partiallink":
+		return goselenium.ByPartialLinkText(value), optional, nil
+	case "text":
+		return goselenium.ByText(value), optional, nil
+	case "partialtext":
+		return goselenium.ByPartialText(value), optional, nil
+	case "label":
+		return goselenium.ByLabel(value), optional, nil
+	case "placeholder":
+		return goselenium.ByPlaceholder(value), optional, nil
+	case "testid":
+		return goselenium.ByTestID(value), optional, nil
+	case "role":
+		role := strings.SplitN(value, ":", 2)
+		if len(role) == 1 {
+			return goselenium.ByRole(role[0], ""), optional, nil
+		}
+		return goselenium.ByRole(role[0], role[1]), optional, nil
+	}
+
+	if _, ok := goselenium.LookupLocatorStrategy(strategy); ok {
+		return goselenium.ByCustom(strategy, value), optional, nil
+	}
+
+	return nil, false, fmt.Errorf("unknown locator strategy %q", strategy)
+}
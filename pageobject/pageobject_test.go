@@ -0,0 +1,249 @@
+package pageobject
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bunsenapp/go-selenium"
+)
+
+const correctResponseErrorText = "An error was returned or the result was not what was expected"
+
+// newTestDriver creates a driver backed by a fake remote end. The elements map
+// is keyed by the locator value (prefixed with the parent element ID and a >
+// for scoped searches) and contains the IDs of the elements to return.
+func newTestDriver(t *testing.T, elements map[string][]string) (goselenium.WebDriver, *[]string, func()) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.URL.Path == "/session" {
+			fmt.Fprint(w, `{"sessionId": "1", "value": {}}`)
+			return
+		}
+
+		var body struct {
+			Value string `json:"value"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		key := body.Value
+		parts := strings.Split(r.URL.Path, "/")
+		if len(parts) == 6 {
+			key = parts[4] + ">" + key
+		}
+
+		refs := []map[string]string{}
+		for _, id := range elements[key] {
+			refs = append(refs, map[string]string{"element-6066-11e4-a52e-4f735466cecf": id})
+		}
+		if strings.HasSuffix(r.URL.Path, "/elements") {
+			json.NewEncoder(w).Encode(map[string]interface{}{"state": "success", "value": refs})
+			return
+		}
+		if len(refs) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"state": "no such element", "value": {"localizedMessage": "not found"}}`)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"state": "success", "value": refs[0]})
+	}))
+
+	caps := goselenium.Capabilities{}
+	caps.SetBrowser(goselenium.FirefoxBrowser())
+	d, err := goselenium.NewSeleniumWebDriver(server.URL, caps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.CreateSession(); err != nil {
+		t.Fatal(err)
+	}
+
+	return d, &requests, server.Close
+}
+
+type headerComponent struct {
+	Logo  goselenium.Element `selenium:"css=.logo"`
+	Links *Elements          `selenium:"tag=a"`
+}
+
+type loginPage struct {
+	Username goselenium.Element `selenium:"id=username"`
+	Errors   *Elements          `selenium:"class=error"`
+	Banner   goselenium.Element `selenium:"css=.banner,optional"`
+	Header   headerComponent    `selenium:"css=header"`
+	Footer   *headerComponent   `selenium:"css=footer,optional"`
+	Form     struct {
+		Submit goselenium.Element `selenium:"css=button[type=submit]"`
+	}
+	notALocator string
+}
+
+func Test_PageObjectInit_InvalidPagesResultInError(t *testing.T) {
+	d, _, closer := newTestDriver(t, nil)
+	defer closer()
+
+	var page loginPage
+	invalid := []interface{}{nil, page, new(string)}
+	for _, i := range invalid {
+		if _, err := Init(d, i); err == nil {
+			t.Errorf("An error was not returned for an invalid page")
+		}
+	}
+}
+
+func Test_PageObjectInit_InvalidTagsResultInError(t *testing.T) {
+	d, _, closer := newTestDriver(t, nil)
+	defer closer()
+
+	pages := []interface{}{
+		&struct {
+			E goselenium.Element `selenium:"css"`
+		}{},
+		&struct {
+			E goselenium.Element `selenium:"unknown=value"`
+		}{},
+		&struct {
+			E string `selenium:"css=#id"`
+		}{},
+		&struct {
+			e goselenium.Element `selenium:"css=#id"`
+		}{},
+		&struct {
+			E []goselenium.Element `selenium:"css=#id"`
+		}{},
+	}
+	for _, p := range pages {
+		if _, err := Init(d, p); err == nil {
+			t.Errorf("An error was not returned for an invalid tag")
+		}
+	}
+}
+
+func Test_PageObjectInit_ElementsAreResolvedLazily(t *testing.T) {
+	d, requests, closer := newTestDriver(t, map[string][]string{
+		"#username":           {"u"},
+		"header":              {"h"},
+		"h>.logo":             {"l"},
+		"button[type=submit]": {"s"},
+	})
+	defer closer()
+
+	var page loginPage
+	_, err := Init(d, &page)
+	if err != nil || len(*requests) != 1 {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	if page.Username.ID() != "u" || page.Header.Logo.ID() != "l" || page.Form.Submit.ID() != "s" {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	page.Username.ID()
+	if len(*requests) != 5 {
+		t.Errorf("Elements were not cached after being resolved")
+	}
+}
+
+func Test_PageObjectValidate_CollectionsAreResolved(t *testing.T) {
+	d, _, closer := newTestDriver(t, map[string][]string{
+		"#username":           {"u"},
+		".error":              {"e1", "e2"},
+		"header":              {"h"},
+		"h>.logo":             {"l"},
+		"h>a":                 {"a1", "a2", "a3"},
+		"button[type=submit]": {"s"},
+	})
+	defer closer()
+
+	var page loginPage
+	p, _ := Init(d, &page)
+
+	err := p.Validate()
+	links, _ := page.Header.Links.All()
+	if err != nil || page.Errors.Len() != 2 || len(links) != 3 || links[2].ID() != "a3" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_PageObjectValidate_MissingRequiredElementsAreReported(t *testing.T) {
+	d, _, closer := newTestDriver(t, map[string][]string{
+		".error": {"e1"},
+		"header": {"h"},
+		"h>a":    {"a1"},
+	})
+	defer closer()
+
+	var page loginPage
+	p, _ := Init(d, &page)
+
+	err := p.Validate()
+	if !IsValidationError(err) {
+		t.Fatalf("An error was not returned or was not of the ValidationError type")
+	}
+
+	missing := err.(ValidationError).Missing
+	if len(missing) != 3 ||
+		missing[0] != "loginPage.Username (css selector=#username)" ||
+		missing[1] != "loginPage.Header.Logo (css selector=.logo)" ||
+		missing[2] != "loginPage.Form.Submit (css selector=button[type=submit])" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+type resultsPage struct {
+	Results *Elements `selenium:"css=.result"`
+	Ads     *Elements `selenium:"css=.ad,optional"`
+	Filters *Elements `selenium:"css=.filter"`
+}
+
+func Test_PageObjectInit_CollectionsAreResolvedLazily(t *testing.T) {
+	d, requests, closer := newTestDriver(t, map[string][]string{
+		".result": {"r1", "r2"},
+	})
+	defer closer()
+
+	var page resultsPage
+	p, err := Init(d, &page)
+	if err != nil || len(*requests) != 1 {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	results, err := page.Results.All()
+	if err != nil || len(results) != 2 || results[1].ID() != "r2" || page.Results.Len() != 2 {
+		t.Errorf(correctResponseErrorText)
+	}
+	if len(*requests) != 2 {
+		t.Errorf("Elements were not cached after being resolved")
+	}
+
+	p.Refresh()
+	page.Results.Len()
+	if len(*requests) != 3 {
+		t.Errorf("Elements were not searched for again after Refresh")
+	}
+}
+
+func Test_PageObjectValidate_EmptyRequiredCollectionsAreReported(t *testing.T) {
+	d, _, closer := newTestDriver(t, map[string][]string{
+		".result": {"r1"},
+	})
+	defer closer()
+
+	var page resultsPage
+	p, _ := Init(d, &page)
+
+	err := p.Validate()
+	if !IsValidationError(err) {
+		t.Fatalf("An error was not returned or was not of the ValidationError type")
+	}
+
+	missing := err.(ValidationError).Missing
+	if len(missing) != 1 || missing[0] != "resultsPage.Filters (css selector=.filter)" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
@@ -3,8 +3,10 @@ package goselenium
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"strings"
+	"sync"
 
 	"errors"
 )
@@ -112,11 +114,104 @@ func ByXPath(path string) By {
 	}
 }
 
+// ByID finds an element by its id attribute. The W3C specification no longer
+// supports locating by ID, so this is translated into an escaped CSS selector.
+func ByID(id string) By {
+	return by{
+		t:     "css selector",
+		value: "#" + cssEscape(id),
+	}
+}
+
+// ByName finds an element by its name attribute. Like ByID, this is translated
+// into an escaped CSS selector.
+func ByName(name string) By {
+	return by{
+		t:     "css selector",
+		value: fmt.Sprintf(`*[name="%s"]`, cssEscape(name)),
+	}
+}
+
+// ByClassName finds an element by its class name. If the name contains
+// whitespace, each class will be required to be present on the element (i.e.
+// "btn primary" will match elements with both the btn and primary classes).
+func ByClassName(name string) By {
+	classes := strings.Fields(name)
+	for i := range classes {
+		classes[i] = "." + cssEscape(classes[i])
+	}
+
+	return by{
+		t:     "css selector",
+		value: strings.Join(classes, ""),
+	}
+}
+
+// ByTagName finds an element by its HTML tag name (i.e. p, div).
+func ByTagName(name string) By {
+	return by{
+		t:     "css selector",
+		value: cssEscape(name),
+	}
+}
+
+// describeBy formats a By for use in error messages and reports.
+func describeBy(b By) string {
+	return fmt.Sprintf("%s=%v", b.Type(), b.Value())
+}
+
+// cssEscape escapes a string so that it can be used as a CSS identifier or
+// within a quoted CSS string. It follows the CSS.escape() algorithm defined
+// in https://drafts.csswg.org/cssom/#serialize-an-identifier.
+func cssEscape(value string) string {
+	var buf bytes.Buffer
+
+	runes := []rune(value)
+	for i, r := range runes {
+		isDigit := r >= '0' && r <= '9'
+		switch {
+		case r == 0:
+			buf.WriteRune('\uFFFD')
+		case (r >= 0x1 && r <= 0x1F) || r == 0x7F,
+			i == 0 && isDigit,
+			i == 1 && isDigit && runes[0] == '-':
+			fmt.Fprintf(&buf, "\\%x ", r)
+		case i == 0 && r == '-' && len(runes) == 1:
+			buf.WriteString("\\-")
+		case r >= 0x80 || r == '-' || r == '_' || isDigit ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			buf.WriteRune(r)
+		default:
+			buf.WriteRune('\\')
+			buf.WriteRune(r)
+		}
+	}
+
+	return buf.String()
+}
+
 type seleniumWebDriver struct {
 	seleniumURL  string
 	sessionID    string
 	capabilities *Capabilities
 	apiService   apiServicer
+	platformName string
+
+	settingsMu    sync.RWMutex
+	staleRecovery bool
+	staleHook     StaleElementHook
+	robustClick   *RobustClickOptions
+
+	// frameMu guards the path of the frame which the driver last switched
+	// to, which is used by WithinFrame to restore the previous frame. Frames
+	// which were switched to by their element have an index of -1.
+	frameMu   sync.Mutex
+	framePath FramePath
+
+	// pinned holds the scripts which the driver uses internally so that they
+	// are only sent in full the first time that they are used on a page.
+	pinnedOnce sync.Once
+	pinned     *ScriptRegistry
 }
 
 func (s *seleniumWebDriver) DriverURL() string {
@@ -176,27 +271,70 @@ func (s *seleniumWebDriver) elementRequest(req *elRequest) ([]byte, error) {
 	return resp, nil
 }
 
-func (s *seleniumWebDriver) scriptRequest(script string, url string, method string) (*ExecuteScriptResponse, error) {
+func (s *seleniumWebDriver) rawValueRequest(req *request) (*rawValueResponse, error) {
+	var response rawValueResponse
+	var err error
+
+	resp, err := s.apiService.performRequest(req.url, req.method, req.body)
+	if err != nil {
+		return nil, newCommunicationError(err, req.callingMethod, req.url, resp)
+	}
+
+	err = json.Unmarshal(resp, &response)
+	if err != nil {
+		return nil, newUnmarshallingError(err, req.callingMethod, string(resp))
+	}
+
+	return &response, nil
+}
+
+// scriptRequest executes a script with arguments on the current page. If async
+// is true, the script is executed asynchronously and its result is the value
+// passed to the callback.
+func (s *seleniumWebDriver) scriptRequest(script string, args []interface{}, async bool, method string) (*ExecuteScriptResponse, error) {
+	url := fmt.Sprintf("%s/session/%s/execute", s.seleniumURL, s.sessionID)
+	if async {
+		url = fmt.Sprintf("%s/session/%s/execute_async", s.seleniumURL, s.sessionID)
+	}
+
+	converted := make([]interface{}, len(args))
+	for i := range args {
+		converted[i] = scriptArgument(args[i])
+	}
 	r := map[string]interface{}{
 		"script": script,
-		"args":   []string{""},
+		"args":   converted,
 	}
 	b, err := json.Marshal(r)
 	if err != nil {
 		return nil, newMarshallingError(err, method, r)
 	}
-	body := bytes.NewReader(b)
-	resp, err := s.valueRequest(&request{
+
+	resp, err := s.rawValueRequest(&request{
 		url:           url,
 		method:        "POST",
-		body:          body,
+		body:          bytes.NewReader(b),
 		callingMethod: method,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &ExecuteScriptResponse{State: resp.State, Response: resp.Value}, nil
+	return newExecuteScriptResponse(resp, s), nil
+}
+
+func (s *seleniumWebDriver) scriptElementsRequest(script string, args []interface{}, method string) ([]Element, error) {
+	resp, err := s.scriptRequest(script, args, false, method)
+	if err != nil {
+		return nil, err
+	}
+
+	elements, err := resp.Elements()
+	if err != nil {
+		return nil, newUnmarshallingError(err, method, string(resp.Value))
+	}
+
+	return elements, nil
 }
 
 type timeout struct {
@@ -235,6 +373,11 @@ type valueResponse struct {
 	Value string `json:"value"`
 }
 
+type rawValueResponse struct {
+	State string          `json:"state"`
+	Value json.RawMessage `json:"value"`
+}
+
 type by struct {
 	t     string
 	value interface{}
@@ -0,0 +1,532 @@
+package goselenium
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Pointer types that can be passed to Actions.Pointer.
+const (
+	MousePointer = "mouse"
+	PenPointer   = "pen"
+	TouchPointer = "touch"
+)
+
+// Mouse buttons that can be pressed and released by a pointer input source.
+const (
+	LeftMouseButton   = 0
+	MiddleMouseButton = 1
+	RightMouseButton  = 2
+)
+
+// DefaultMoveDuration is how long the pointer takes to move when it is moved
+// by one of the high level Actions methods.
+const DefaultMoveDuration = 250 * time.Millisecond
+
+// The IDs of the input sources that are used by the high level Actions
+// methods.
+const (
+	defaultKeyID     = "keyboard"
+	defaultPointerID = "mouse"
+	defaultWheelID   = "wheel"
+)
+
+// Origin is what the co-ordinates of a pointer move or scroll are relative
+// to.
+type Origin struct {
+	name string
+	el   Element
+}
+
+// ViewportOrigin makes co-ordinates relative to the top left of the viewport.
+var ViewportOrigin = Origin{name: "viewport"}
+
+// PointerOrigin makes co-ordinates relative to the current position of the
+// pointer.
+var PointerOrigin = Origin{name: "pointer"}
+
+// ElementOrigin makes co-ordinates relative to the centre of an element.
+func ElementOrigin(el Element) Origin {
+	return Origin{el: el}
+}
+
+// MarshalJSON serializes the origin in the form expected by the remote end.
+// The ID of an element is read when the origin is serialized so that elements
+// which have been recovered after becoming stale are referenced correctly.
+func (o Origin) MarshalJSON() ([]byte, error) {
+	if o.el != nil {
+		return json.Marshal(elementReference(o.el.ID()))
+	}
+	if o.name == "" {
+		return json.Marshal(ViewportOrigin.name)
+	}
+
+	return json.Marshal(o.name)
+}
+
+// Actions builds a set of W3C action sequences which are performed by calling
+// PerformActions. It is created by calling NewActions.
+//
+// Each input source has a list of actions; when they are performed, the first
+// action of every source is dispatched, followed by the second, and so on
+// (each of these steps being a 'tick'). Input sources can be controlled
+// individually through Key, Pointer and Wheel, or the high level methods on
+// Actions can be used; these wait for every previous action to finish before
+// starting.
+type Actions struct {
+	sources []*inputSource
+	err     error
+}
+
+type inputSource struct {
+	Type       string             `json:"type"`
+	ID         string             `json:"id"`
+	Parameters *pointerParameters `json:"parameters,omitempty"`
+	Actions    []action           `json:"actions"`
+}
+
+type pointerParameters struct {
+	PointerType string `json:"pointerType"`
+}
+
+type action map[string]interface{}
+
+// NewActions creates an empty set of actions.
+func NewActions() *Actions {
+	return &Actions{}
+}
+
+// KeyInput is a keyboard input source.
+type KeyInput struct {
+	a   *Actions
+	src *inputSource
+}
+
+// PointerInput is a mouse, pen or touch input source.
+type PointerInput struct {
+	a   *Actions
+	src *inputSource
+}
+
+// WheelInput is a scroll wheel input source.
+type WheelInput struct {
+	a   *Actions
+	src *inputSource
+}
+
+// Key returns the keyboard input source with the ID passed in, creating it if
+// it does not exist.
+func (a *Actions) Key(id string) *KeyInput {
+	return &KeyInput{a: a, src: a.source("key", id, "")}
+}
+
+// Pointer returns the pointer input source with the ID passed in, creating it
+// if it does not exist. The pointer type is one of MousePointer, PenPointer
+// or TouchPointer.
+func (a *Actions) Pointer(id string, pointerType string) *PointerInput {
+	return &PointerInput{a: a, src: a.source("pointer", id, pointerType)}
+}
+
+// Wheel returns the wheel input source with the ID passed in, creating it if
+// it does not exist.
+func (a *Actions) Wheel(id string) *WheelInput {
+	return &WheelInput{a: a, src: a.source("wheel", id, "")}
+}
+
+func (a *Actions) source(t string, id string, pointerType string) *inputSource {
+	for _, s := range a.sources {
+		if s.ID != id {
+			continue
+		}
+		if s.Type != t || (s.Parameters != nil && s.Parameters.PointerType != pointerType) {
+			a.setErr(fmt.Errorf("actions: input source %q already exists with a different type", id))
+		}
+		return s
+	}
+
+	s := &inputSource{Type: t, ID: id, Actions: []action{}}
+	if t == "pointer" {
+		if pointerType == "" {
+			pointerType = MousePointer
+		}
+		s.Parameters = &pointerParameters{PointerType: pointerType}
+	}
+	a.sources = append(a.sources, s)
+
+	return s
+}
+
+func (a *Actions) setErr(err error) {
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+// sync pads every input source with pauses so that the next action added to
+// any of them is only dispatched once all previous actions have completed.
+func (a *Actions) sync() {
+	var ticks int
+	for _, s := range a.sources {
+		if len(s.Actions) > ticks {
+			ticks = len(s.Actions)
+		}
+	}
+
+	for _, s := range a.sources {
+		for len(s.Actions) < ticks {
+			s.Actions = append(s.Actions, pauseAction(0))
+		}
+	}
+}
+
+// MarshalJSON serializes the actions in the form expected by the remote end.
+func (a *Actions) MarshalJSON() ([]byte, error) {
+	sources := a.sources
+	if sources == nil {
+		sources = []*inputSource{}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"actions": sources,
+	})
+}
+
+func pauseAction(d time.Duration) action {
+	return action{"type": "pause", "duration": milliseconds(d)}
+}
+
+func milliseconds(d time.Duration) int64 {
+	return int64(d / time.Millisecond)
+}
+
+// Pause adds a pause to the keyboard input source.
+func (k *KeyInput) Pause(d time.Duration) *KeyInput {
+	k.src.Actions = append(k.src.Actions, pauseAction(d))
+	return k
+}
+
+// KeyDown presses a key. The key must be a single character, such as "a" or
+// ShiftKey.
+func (k *KeyInput) KeyDown(key string) *KeyInput {
+	return k.key("keyDown", key)
+}
+
+// KeyUp releases a key that was pressed by KeyDown.
+func (k *KeyInput) KeyUp(key string) *KeyInput {
+	return k.key("keyUp", key)
+}
+
+func (k *KeyInput) key(t string, key string) *KeyInput {
+	if len([]rune(key)) != 1 {
+		k.a.setErr(fmt.Errorf("actions: invalid key %q, a key must be a single character", key))
+		return k
+	}
+
+	k.src.Actions = append(k.src.Actions, action{"type": t, "value": key})
+	return k
+}
+
+// Pause adds a pause to the pointer input source.
+func (p *PointerInput) Pause(d time.Duration) *PointerInput {
+	p.src.Actions = append(p.src.Actions, pauseAction(d))
+	return p
+}
+
+// Move moves the pointer to x and y (relative to the origin) over the
+// duration passed in.
+func (p *PointerInput) Move(origin Origin, x int, y int, d time.Duration) *PointerInput {
+	p.src.Actions = append(p.src.Actions, action{
+		"type":     "pointerMove",
+		"origin":   origin,
+		"x":        x,
+		"y":        y,
+		"duration": milliseconds(d),
+	})
+	return p
+}
+
+// Down presses a button of the pointer (i.e. LeftMouseButton).
+func (p *PointerInput) Down(button int) *PointerInput {
+	p.src.Actions = append(p.src.Actions, action{"type": "pointerDown", "button": button})
+	return p
+}
+
+// Up releases a button of the pointer that was pressed by Down.
+func (p *PointerInput) Up(button int) *PointerInput {
+	p.src.Actions = append(p.src.Actions, action{"type": "pointerUp", "button": button})
+	return p
+}
+
+// Cancel cancels the current pointer interaction.
+func (p *PointerInput) Cancel() *PointerInput {
+	p.src.Actions = append(p.src.Actions, action{"type": "pointerCancel"})
+	return p
+}
+
+// Pause adds a pause to the wheel input source.
+func (w *WheelInput) Pause(d time.Duration) *WheelInput {
+	w.src.Actions = append(w.src.Actions, pauseAction(d))
+	return w
+}
+
+// Scroll scrolls by deltaX and deltaY from the point x and y (relative to the
+// origin) over the duration passed in. The origin cannot be PointerOrigin.
+func (w *WheelInput) Scroll(origin Origin, x int, y int, deltaX int, deltaY int, d time.Duration) *WheelInput {
+	if origin.el == nil && origin.name == PointerOrigin.name {
+		w.a.setErr(errors.New("actions: a scroll cannot be relative to the pointer"))
+		return w
+	}
+
+	w.src.Actions = append(w.src.Actions, action{
+		"type":     "scroll",
+		"origin":   origin,
+		"x":        x,
+		"y":        y,
+		"deltaX":   deltaX,
+		"deltaY":   deltaY,
+		"duration": milliseconds(d),
+	})
+	return w
+}
+
+func (a *Actions) mouse() *PointerInput {
+	p := a.Pointer(defaultPointerID, MousePointer)
+	a.sync()
+	return p
+}
+
+func (a *Actions) keyboard() *KeyInput {
+	k := a.Key(defaultKeyID)
+	a.sync()
+	return k
+}
+
+func (a *Actions) wheel() *WheelInput {
+	w := a.Wheel(defaultWheelID)
+	a.sync()
+	return w
+}
+
+// moveTo moves the mouse to the centre of the element, unless it is nil in
+// which case the mouse stays where it is.
+func (a *Actions) moveTo(el Element) *PointerInput {
+	m := a.mouse()
+	if el != nil {
+		m.Move(ElementOrigin(el), 0, 0, DefaultMoveDuration)
+	}
+
+	return m
+}
+
+// Pause waits for every previous action to finish and then pauses all of the
+// input sources for the duration passed in.
+func (a *Actions) Pause(d time.Duration) *Actions {
+	if len(a.sources) == 0 {
+		a.Pointer(defaultPointerID, MousePointer)
+	}
+	a.sync()
+	for _, s := range a.sources {
+		s.Actions = append(s.Actions, pauseAction(d))
+	}
+
+	return a
+}
+
+// MoveToElement moves the mouse to x and y relative to the centre of the
+// element, which is how hover effects are triggered.
+func (a *Actions) MoveToElement(el Element, x int, y int) *Actions {
+	if el == nil {
+		a.setErr(errors.New("movetoelement: invalid element argument"))
+		return a
+	}
+
+	a.mouse().Move(ElementOrigin(el), x, y, DefaultMoveDuration)
+	return a
+}
+
+// MoveByOffset moves the mouse by x and y from its current position.
+func (a *Actions) MoveByOffset(x int, y int) *Actions {
+	a.mouse().Move(PointerOrigin, x, y, DefaultMoveDuration)
+	return a
+}
+
+// MoveToLocation moves the mouse to x and y relative to the top left of the
+// viewport.
+func (a *Actions) MoveToLocation(x int, y int) *Actions {
+	a.mouse().Move(ViewportOrigin, x, y, DefaultMoveDuration)
+	return a
+}
+
+// Click clicks the left mouse button in the centre of the element. If the
+// element is nil, the mouse is clicked wherever it currently is.
+func (a *Actions) Click(el Element) *Actions {
+	a.moveTo(el).Down(LeftMouseButton).Up(LeftMouseButton)
+	return a
+}
+
+// DoubleClick double clicks the left mouse button in the centre of the
+// element. If the element is nil, the mouse is clicked wherever it currently
+// is.
+func (a *Actions) DoubleClick(el Element) *Actions {
+	a.moveTo(el).
+		Down(LeftMouseButton).Up(LeftMouseButton).
+		Down(LeftMouseButton).Up(LeftMouseButton)
+	return a
+}
+
+// ContextClick clicks the right mouse button in the centre of the element.
+// If the element is nil, the mouse is clicked wherever it currently is.
+func (a *Actions) ContextClick(el Element) *Actions {
+	a.moveTo(el).Down(RightMouseButton).Up(RightMouseButton)
+	return a
+}
+
+// ClickAndHold presses the left mouse button in the centre of the element
+// without releasing it. If the element is nil, the mouse is pressed wherever
+// it currently is.
+func (a *Actions) ClickAndHold(el Element) *Actions {
+	a.moveTo(el).Down(LeftMouseButton)
+	return a
+}
+
+// Release releases the left mouse button in the centre of the element. If the
+// element is nil, the mouse is released wherever it currently is.
+func (a *Actions) Release(el Element) *Actions {
+	a.moveTo(el).Up(LeftMouseButton)
+	return a
+}
+
+// DragAndDrop presses the left mouse button on the source element, moves to
+// the target element and releases it.
+func (a *Actions) DragAndDrop(source Element, target Element) *Actions {
+	if source == nil || target == nil {
+		a.setErr(errors.New("draganddrop: invalid element argument"))
+		return a
+	}
+
+	return a.ClickAndHold(source).Release(target)
+}
+
+// DragAndDropBy presses the left mouse button on the source element, moves
+// the mouse by x and y and releases it.
+func (a *Actions) DragAndDropBy(source Element, x int, y int) *Actions {
+	if source == nil {
+		a.setErr(errors.New("draganddropby: invalid element argument"))
+		return a
+	}
+
+	a.ClickAndHold(source)
+	a.mouse().Move(PointerOrigin, x, y, DefaultMoveDuration).Up(LeftMouseButton)
+	return a
+}
+
+// KeyDown presses a key (usually a modifier such as ControlKey) without
+// releasing it.
+func (a *Actions) KeyDown(key string) *Actions {
+	a.keyboard().KeyDown(key)
+	return a
+}
+
+// KeyUp releases a key that was pressed by KeyDown.
+func (a *Actions) KeyUp(key string) *Actions {
+	a.keyboard().KeyUp(key)
+	return a
+}
+
+// SendKeys presses and releases each character of keys in turn. Any keys held
+// down by KeyDown remain pressed, allowing combinations such as Ctrl+A.
+func (a *Actions) SendKeys(keys string) *Actions {
+	k := a.keyboard()
+	for _, r := range keys {
+		k.KeyDown(string(r)).KeyUp(string(r))
+	}
+
+	return a
+}
+
+// ScrollByAmount scrolls the viewport by deltaX and deltaY.
+func (a *Actions) ScrollByAmount(deltaX int, deltaY int) *Actions {
+	a.wheel().Scroll(ViewportOrigin, 0, 0, deltaX, deltaY, 0)
+	return a
+}
+
+// ScrollFromElement scrolls by deltaX and deltaY from the centre of the
+// element. If the element is outside of the viewport, it is scrolled into
+// view first.
+func (a *Actions) ScrollFromElement(el Element, deltaX int, deltaY int) *Actions {
+	if el == nil {
+		a.setErr(errors.New("scrollfromelement: invalid element argument"))
+		return a
+	}
+
+	a.wheel().Scroll(ElementOrigin(el), 0, 0, deltaX, deltaY, 0)
+	return a
+}
+
+// PerformActionsResponse is the response returned from calling the
+// PerformActions method.
+type PerformActionsResponse struct {
+	State string
+}
+
+// ReleaseActionsResponse is the response returned from calling the
+// ReleaseActions method.
+type ReleaseActionsResponse struct {
+	State string
+}
+
+func (s *seleniumWebDriver) PerformActions(a *Actions) (*PerformActionsResponse, error) {
+	if a == nil {
+		return nil, errors.New("performactions: invalid actions argument")
+	} else if a.err != nil {
+		return nil, a.err
+	} else if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("PerformActions")
+	}
+
+	var err error
+
+	url := fmt.Sprintf("%s/session/%s/actions", s.seleniumURL, s.sessionID)
+
+	body, err := json.Marshal(a)
+	if err != nil {
+		return nil, newMarshallingError(err, "PerformActions", a)
+	}
+
+	resp, err := s.stateRequest(&request{
+		url:           url,
+		method:        "POST",
+		body:          bytes.NewReader(body),
+		callingMethod: "PerformActions",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PerformActionsResponse{State: resp.State}, nil
+}
+
+func (s *seleniumWebDriver) ReleaseActions() (*ReleaseActionsResponse, error) {
+	if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("ReleaseActions")
+	}
+
+	var err error
+
+	url := fmt.Sprintf("%s/session/%s/actions", s.seleniumURL, s.sessionID)
+
+	resp, err := s.stateRequest(&request{
+		url:           url,
+		method:        "DELETE",
+		body:          nil,
+		callingMethod: "ReleaseActions",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReleaseActionsResponse{State: resp.State}, nil
+}
@@ -0,0 +1,258 @@
+package goselenium
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sequences decodes the JSON of a set of actions so that tests can inspect
+// the actions of each input source.
+func sequences(t *testing.T, a *Actions) []map[string]interface{} {
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var payload struct {
+		Actions []map[string]interface{} `json:"actions"`
+	}
+	err = json.Unmarshal(b, &payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return payload.Actions
+}
+
+func actionTypes(source map[string]interface{}) []string {
+	var types []string
+	for _, a := range source["actions"].([]interface{}) {
+		types = append(types, a.(map[string]interface{})["type"].(string))
+	}
+
+	return types
+}
+
+/*
+	PerformActions() Tests
+*/
+
+func Test_ActionsPerformActions_InvalidSessionIdResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	_, err := d.PerformActions(NewActions().Click(nil))
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_ActionsPerformActions_InvalidActionsResultInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	d.sessionID = "12345"
+
+	reused := NewActions()
+	reused.Pointer("a", MousePointer)
+	reused.Key("a")
+
+	invalid := []*Actions{
+		nil,
+		NewActions().MoveToElement(nil, 0, 0),
+		NewActions().DragAndDrop(nil, nil),
+		NewActions().KeyDown("ab"),
+		reused,
+	}
+	for _, a := range invalid {
+		_, err := d.PerformActions(a)
+		if err == nil {
+			t.Errorf(argumentErrorText)
+		}
+	}
+}
+
+func Test_ActionsPerformActions_CommunicationErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: errors.New("An error :<"),
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.PerformActions(NewActions().Click(nil))
+	if err == nil || !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_ActionsPerformActions_CorrectResponseIsReturned(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success"}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.PerformActions(NewActions().ContextClick(newSeleniumElement("abc", d)))
+	if err != nil || resp.State != "success" || !strings.HasSuffix(api.lastURL, "/session/12345/actions") {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	var payload struct {
+		Actions []struct {
+			Type       string
+			ID         string
+			Parameters struct{ PointerType string }
+			Actions    []map[string]interface{}
+		}
+	}
+	err = json.Unmarshal([]byte(api.lastBody), &payload)
+	if err != nil || len(payload.Actions) != 1 {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	mouse := payload.Actions[0]
+	if mouse.Type != "pointer" || mouse.Parameters.PointerType != "mouse" || len(mouse.Actions) != 3 {
+		t.Fatalf(correctResponseErrorText)
+	}
+	origin := mouse.Actions[0]["origin"].(map[string]interface{})
+	if origin[webElementIdentifier] != "abc" || mouse.Actions[1]["button"] != float64(RightMouseButton) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+	ReleaseActions() Tests
+*/
+
+func Test_ActionsReleaseActions_InvalidSessionIdResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	_, err := d.ReleaseActions()
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_ActionsReleaseActions_CorrectResponseIsReturned(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/actions": `{"state": "success"}`,
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.ReleaseActions()
+	if err != nil || resp.State != "success" || !strings.HasPrefix(api.requests[0], "DELETE ") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+	Actions builder Tests
+*/
+
+func Test_Actions_HighLevelActionsWaitForEachOther(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	el := newSeleniumElement("abc", d)
+
+	a := NewActions().KeyDown(ControlKey).Click(el).KeyUp(ControlKey)
+	s := sequences(t, a)
+	if len(s) != 2 {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	keys, mouse := actionTypes(s[0]), actionTypes(s[1])
+	expectedKeys := []string{"keyDown", "pause", "pause", "pause", "keyUp"}
+	expectedMouse := []string{"pause", "pointerMove", "pointerDown", "pointerUp"}
+	if len(keys) != len(expectedKeys) || len(mouse) != len(expectedMouse) {
+		t.Fatalf(correctResponseErrorText)
+	}
+	for i := range expectedKeys {
+		if keys[i] != expectedKeys[i] {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+	for i := range expectedMouse {
+		if mouse[i] != expectedMouse[i] {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_Actions_InputSourcesCanBeControlledIndividually(t *testing.T) {
+	a := NewActions()
+	a.Pointer("pen", PenPointer).Move(ViewportOrigin, 10, 20, time.Second).Down(LeftMouseButton)
+	a.Key("keys").KeyDown("a")
+	a.Wheel("scroll").Scroll(ViewportOrigin, 0, 0, 0, 100, 0)
+
+	s := sequences(t, a)
+	if len(s) != 3 || s[0]["id"] != "pen" || len(actionTypes(s[0])) != 2 || len(actionTypes(s[1])) != 1 {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	move := s[0]["actions"].([]interface{})[0].(map[string]interface{})
+	if move["origin"] != "viewport" || move["duration"] != float64(1000) || move["x"] != float64(10) {
+		t.Errorf(correctResponseErrorText)
+	}
+	if s[0]["parameters"].(map[string]interface{})["pointerType"] != "pen" {
+		t.Errorf(correctResponseErrorText)
+	}
+	if _, ok := s[1]["parameters"]; ok {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Actions_ScrollRelativeToPointerResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	d.sessionID = "12345"
+
+	a := NewActions()
+	a.Wheel("wheel").Scroll(PointerOrigin, 0, 0, 0, 10, 0)
+
+	_, err := d.PerformActions(a)
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_Actions_DragAndDropMovesBetweenElements(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	source, target := newSeleniumElement("a", d), newSeleniumElement("b", d)
+
+	s := sequences(t, NewActions().DragAndDrop(source, target))
+	types := actionTypes(s[0])
+	expected := []string{"pointerMove", "pointerDown", "pointerMove", "pointerUp"}
+	if len(types) != len(expected) {
+		t.Fatalf(correctResponseErrorText)
+	}
+	for i := range expected {
+		if types[i] != expected[i] {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+
+	drop := s[0]["actions"].([]interface{})[2].(map[string]interface{})
+	if drop["origin"].(map[string]interface{})[webElementIdentifier] != "b" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Actions_PauseIsAddedToEverySource(t *testing.T) {
+	s := sequences(t, NewActions().SendKeys("ab").ScrollByAmount(0, 10).Pause(time.Second))
+	if len(s) != 2 {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	for _, source := range s {
+		actions := source["actions"].([]interface{})
+		if len(actions) != 6 {
+			t.Fatalf(correctResponseErrorText)
+		}
+		last := actions[len(actions)-1].(map[string]interface{})
+		if last["type"] != "pause" || last["duration"] != float64(1000) {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
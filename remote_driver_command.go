@@ -27,6 +27,7 @@ type CloseWindowResponse struct {
 // method. You can verify that this result is correct by calling the
 // WindowHandle() method. The two should match.
 type SwitchToWindowResponse struct {
+	State string
 }
 
 // WindowHandlesResponse is the response returned from the WindowHandles()
@@ -128,7 +129,39 @@ func (s *seleniumWebDriver) CloseWindow() (*CloseWindowResponse, error) {
 }
 
 func (s *seleniumWebDriver) SwitchToWindow(handle string) (*SwitchToWindowResponse, error) {
-	return nil, nil
+	if len(handle) == 0 {
+		return nil, errors.New("switchtowindow: invalid handle argument")
+	} else if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("SwitchToWindow")
+	}
+
+	var err error
+
+	url := fmt.Sprintf("%s/session/%s/window", s.seleniumURL, s.sessionID)
+
+	// The W3C specification uses handle whereas older drivers use name.
+	params := map[string]string{
+		"handle": handle,
+		"name":   handle,
+	}
+	requestJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, newMarshallingError(err, "SwitchToWindow", params)
+	}
+
+	body := bytes.NewReader(requestJSON)
+	resp, err := s.stateRequest(&request{
+		url:           url,
+		method:        "POST",
+		body:          body,
+		callingMethod: "SwitchToWindow",
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.setFramePath(FramePath{})
+
+	return &SwitchToWindowResponse{State: resp.State}, nil
 }
 
 func (s *seleniumWebDriver) WindowHandles() (*WindowHandlesResponse, error) {
@@ -158,16 +191,40 @@ func (s *seleniumWebDriver) SwitchToFrame(by By) (*SwitchToFrameResponse, error)
 	if len(s.sessionID) == 0 {
 		return nil, newSessionIDError("SwitchToFrame")
 	}
-	if by == nil || (by.Type() != "index") {
+	if by == nil {
 		return nil, errors.New("switchtoframe: invalid by argument")
 	}
 
+	var id interface{}
+	switch b := by.(type) {
+	case elementBy:
+		id = elementReference(b.el.ID())
+	default:
+		if by.Type() == "index" {
+			id = by.Value()
+			break
+		}
+
+		el, err := s.FindElement(by)
+		if err != nil {
+			return nil, err
+		}
+		id = elementReference(el.ID())
+	}
+
+	return s.switchToFrame(id)
+}
+
+// switchToFrame switches to the frame identified by id, which is either the
+// index of the frame, a reference to the frame element or nil for the top
+// level browsing context.
+func (s *seleniumWebDriver) switchToFrame(id interface{}) (*SwitchToFrameResponse, error) {
 	var err error
 
 	url := fmt.Sprintf("%s/session/%s/frame", s.seleniumURL, s.sessionID)
 
 	params := map[string]interface{}{
-		"id": by.Value(),
+		"id": id,
 	}
 	requestJSON, err := json.Marshal(params)
 	if err != nil {
@@ -184,6 +241,7 @@ func (s *seleniumWebDriver) SwitchToFrame(by By) (*SwitchToFrameResponse, error)
 	if err != nil {
 		return nil, err
 	}
+	s.enteredFrame(id)
 
 	return &SwitchToFrameResponse{State: resp.State}, nil
 }
@@ -206,6 +264,7 @@ func (s *seleniumWebDriver) SwitchToParentFrame() (*SwitchToParentFrameResponse,
 	if err != nil {
 		return nil, err
 	}
+	s.leftFrame()
 
 	return &SwitchToParentFrameResponse{State: resp.State}, nil
 }
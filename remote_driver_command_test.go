@@ -115,6 +115,59 @@ func Test_CommandCloseWindow_UnmarshallingErrorIsReturned(t *testing.T) {
 /*
 	SwitchToWindow() Tests
 */
+func Test_CommandSwitchToWindow_InvalidHandleResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	d.sessionID = "12345"
+
+	_, err := d.SwitchToWindow("")
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_CommandSwitchToWindow_InvalidSessionIdResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	_, err := d.SwitchToWindow("CDwindow-1")
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_CommandSwitchToWindow_APICommunicationErrorIsReturned(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: errors.New("An error"),
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.SwitchToWindow("CDwindow-1")
+	if err == nil || !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_CommandSwitchToWindow_CorrectResponseIsReturned(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success"
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.SwitchToWindow("CDwindow-1")
+	if err != nil || resp.State != "success" {
+		t.Errorf(correctResponseErrorText)
+	}
+	if api.lastBody != `{"handle":"CDwindow-1","name":"CDwindow-1"}` {
+		t.Errorf(correctResponseErrorText)
+	}
+}
 
 /*
 	WindowHandles() Tests
@@ -0,0 +1,472 @@
+package goselenium
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// visibleScript checks whether arguments[0] is rendered and is not hidden by
+// its style, in the same way as the user-facing locators.
+const visibleScript = `
+var e = arguments[0];
+if (!e.getClientRects().length) { return false; }
+var style = window.getComputedStyle(e);
+return style.visibility !== 'hidden' && style.display !== 'none';
+`
+
+// truthyScript runs a script as the body of a function, passing it the
+// arguments, and converts the value that it returns to a boolean.
+const truthyScript = `
+return !!(function() {
+/* script */
+}).apply(null, arguments);
+`
+
+// described is a Condition made up of a description and the function which
+// checks it. It is what each of the conditions below return.
+type described struct {
+	description string
+	check       func(w WebDriver) (bool, error)
+}
+
+func (d described) Description() string {
+	return d.description
+}
+
+func (d described) Check(w WebDriver) (bool, error) {
+	return d.check(w)
+}
+
+// NewCondition creates a Condition from a description (which completes the
+// sentence "timed out waiting for ...") and the function which checks it.
+func NewCondition(description string, check func(w WebDriver) (bool, error)) Condition {
+	return described{description: description, check: check}
+}
+
+// UntilTitleIs waits until the title of the page is the title passed in.
+func UntilTitleIs(title string) Condition {
+	return NewCondition(fmt.Sprintf("title to be %q", title), func(w WebDriver) (bool, error) {
+		resp, err := w.Title()
+		if err != nil {
+			return false, err
+		}
+
+		return resp.Title == title, nil
+	})
+}
+
+// UntilTitleContains waits until the title of the page contains the string
+// passed in.
+func UntilTitleContains(substr string) Condition {
+	return NewCondition(fmt.Sprintf("title to contain %q", substr), func(w WebDriver) (bool, error) {
+		resp, err := w.Title()
+		if err != nil {
+			return false, err
+		}
+
+		return strings.Contains(resp.Title, substr), nil
+	})
+}
+
+// UntilURLContains waits until the URL of the page contains the string passed
+// in.
+func UntilURLContains(substr string) Condition {
+	return NewCondition(fmt.Sprintf("URL to contain %q", substr), func(w WebDriver) (bool, error) {
+		resp, err := w.CurrentURL()
+		if err != nil {
+			return false, err
+		}
+
+		return strings.Contains(resp.URL, substr), nil
+	})
+}
+
+// UntilURLMatches waits until the URL of the page matches the regular
+// expression passed in.
+func UntilURLMatches(re *regexp.Regexp) Condition {
+	if re == nil {
+		return invalidCondition("untilurlmatches: invalid re argument")
+	}
+
+	return NewCondition(fmt.Sprintf("URL to match %q", re.String()), func(w WebDriver) (bool, error) {
+		resp, err := w.CurrentURL()
+		if err != nil {
+			return false, err
+		}
+
+		return re.MatchString(resp.URL), nil
+	})
+}
+
+// UntilElementVisible waits until an element matching by is found and is
+// displayed on the page.
+func UntilElementVisible(by By) Condition {
+	return elementCondition(by, "to be visible", isVisible)
+}
+
+// UntilElementInvisible waits until none of the elements matching by are
+// displayed on the page, including when they have all been removed. Elements
+// which become stale whilst being checked count as hidden.
+func UntilElementInvisible(by By) Condition {
+	if by == nil {
+		return invalidCondition("untilelementinvisible: invalid by argument")
+	}
+
+	return NewCondition(fmt.Sprintf("element %s to be invisible", describeBy(by)), func(w WebDriver) (bool, error) {
+		elements, err := w.FindElements(by)
+		if hasErrorState(err, NoSuchElement) {
+			return true, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		for _, el := range elements {
+			visible, err := isVisible(w, el)
+			if isStaleElementError(err) {
+				continue
+			} else if err != nil {
+				return false, err
+			}
+			if visible {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+}
+
+// UntilElementClickable waits until an element matching by is found, is
+// displayed and is enabled.
+func UntilElementClickable(by By) Condition {
+	return elementCondition(by, "to be clickable", func(w WebDriver, el Element) (bool, error) {
+		visible, err := isVisible(w, el)
+		if err != nil || !visible {
+			return false, err
+		}
+
+		resp, err := el.Enabled()
+		if err != nil {
+			return false, err
+		}
+
+		return resp.Enabled, nil
+	})
+}
+
+// UntilElementSelected waits until an element matching by is found and is
+// selected (i.e. a checked checkbox or a selected option).
+func UntilElementSelected(by By) Condition {
+	return elementCondition(by, "to be selected", func(w WebDriver, el Element) (bool, error) {
+		resp, err := el.Selected()
+		if err != nil {
+			return false, err
+		}
+
+		return resp.Selected, nil
+	})
+}
+
+// UntilTextContains waits until an element matching by is found and its text
+// contains the string passed in.
+func UntilTextContains(by By, substr string) Condition {
+	return elementCondition(by, fmt.Sprintf("to have text containing %q", substr), func(w WebDriver, el Element) (bool, error) {
+		resp, err := el.Text()
+		if err != nil {
+			return false, err
+		}
+
+		return strings.Contains(resp.Text, substr), nil
+	})
+}
+
+// UntilAttributeIs waits until an element matching by is found and the value
+// of one of its attributes is the value passed in.
+func UntilAttributeIs(by By, name string, value string) Condition {
+	return elementCondition(by, fmt.Sprintf("to have attribute %s=%q", name, value), func(w WebDriver, el Element) (bool, error) {
+		resp, err := el.Attribute(name)
+		if err != nil {
+			return false, err
+		}
+
+		return resp.Value == value, nil
+	})
+}
+
+// UntilCSSValueIs waits until an element matching by is found and the
+// computed value of one of its CSS properties is the value passed in.
+func UntilCSSValueIs(by By, property string, value string) Condition {
+	return elementCondition(by, fmt.Sprintf("to have CSS %s=%q", property, value), func(w WebDriver, el Element) (bool, error) {
+		resp, err := el.CSSValue(property)
+		if err != nil {
+			return false, err
+		}
+
+		return resp.Value == value, nil
+	})
+}
+
+// UntilElementCount waits until exactly count elements match by.
+func UntilElementCount(by By, count int) Condition {
+	if by == nil || count < 0 {
+		return invalidCondition("untilelementcount: invalid argument")
+	}
+
+	return NewCondition(fmt.Sprintf("%d elements matching %s", count, describeBy(by)), func(w WebDriver) (bool, error) {
+		elements, err := w.FindElements(by)
+		if err != nil {
+			return false, err
+		}
+
+		return len(elements) == count, nil
+	})
+}
+
+// UntilStale waits until an element that has already been found is no longer
+// attached to the page (i.e. it has been removed or the page has been
+// navigated away from). Stale element recovery is not used whilst checking.
+func UntilStale(el Element) Condition {
+	if el == nil {
+		return invalidCondition("untilstale: invalid el argument")
+	}
+
+	return NewCondition(fmt.Sprintf("element %s to become stale", el.ID()), func(w WebDriver) (bool, error) {
+		resp, err := w.ExecuteScript("return arguments[0].isConnected;", el)
+		if isStaleElementError(err) || hasErrorState(err, NoSuchElement) {
+			return true, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		connected := true
+		err = resp.Decode(&connected)
+		if err != nil {
+			return false, newUnmarshallingError(err, "UntilStale", string(resp.Value))
+		}
+
+		return !connected, nil
+	})
+}
+
+// UntilAlertPresent waits until an alert, confirm or prompt is open.
+func UntilAlertPresent() Condition {
+	return NewCondition("an alert to be present", func(w WebDriver) (bool, error) {
+		_, err := w.AlertText()
+		if hasErrorState(err, NoSuchAlert) {
+			return false, nil
+		}
+
+		return err == nil, err
+	})
+}
+
+// UntilFrameAvailable waits until the frame determined by by (see
+// SwitchToFrame) can be switched to and switches to it.
+func UntilFrameAvailable(by By) Condition {
+	if by == nil {
+		return invalidCondition("untilframeavailable: invalid by argument")
+	}
+
+	return NewCondition(fmt.Sprintf("frame %s to be available", describeBy(by)), func(w WebDriver) (bool, error) {
+		_, err := w.SwitchToFrame(by)
+		return err == nil, err
+	})
+}
+
+// UntilWindowCount waits until exactly count windows (or tabs) are open.
+func UntilWindowCount(count int) Condition {
+	return NewCondition(fmt.Sprintf("%d windows to be open", count), func(w WebDriver) (bool, error) {
+		resp, err := w.WindowHandles()
+		if err != nil {
+			return false, err
+		}
+
+		return len(resp.Handles) == count, nil
+	})
+}
+
+// UntilScriptTruthy waits until a script returns a truthy value. As with
+// ExecuteScript, the script is the body of a function which is passed the
+// arguments (i.e. "return window.appReady;").
+func UntilScriptTruthy(script string, args ...interface{}) Condition {
+	if strings.TrimSpace(script) == "" {
+		return invalidCondition("untilscripttruthy: invalid script argument")
+	}
+
+	return NewCondition(fmt.Sprintf("script %q to be truthy", script), func(w WebDriver) (bool, error) {
+		resp, err := w.ExecuteScript(strings.Replace(truthyScript, scriptPlaceholder, script, 1), args...)
+		if err != nil {
+			return false, err
+		}
+
+		var truthy bool
+		err = resp.Decode(&truthy)
+		if err != nil {
+			return false, newUnmarshallingError(err, "UntilScriptTruthy", string(resp.Value))
+		}
+
+		return truthy, nil
+	})
+}
+
+// elementCondition finds the element matching by and checks it, treating a
+// missing or stale element as the condition not being satisfied yet.
+func elementCondition(by By, expectation string, check func(w WebDriver, el Element) (bool, error)) Condition {
+	if by == nil {
+		return invalidCondition("condition: invalid by argument")
+	}
+
+	return NewCondition(fmt.Sprintf("element %s %s", describeBy(by), expectation), func(w WebDriver) (bool, error) {
+		el, err := w.FindElement(by)
+		if err != nil {
+			return false, err
+		}
+
+		return check(w, el)
+	})
+}
+
+// invalidConditionError is returned from checking a condition which was
+// created with invalid arguments. Waits return it straight away rather than
+// retrying the condition until they time out.
+type invalidConditionError struct {
+	message string
+}
+
+func (i invalidConditionError) Error() string {
+	return i.message
+}
+
+// invalid is the Condition returned when a condition is created with invalid
+// arguments. Checking it always returns an invalidConditionError.
+type invalid struct {
+	err invalidConditionError
+}
+
+func (i invalid) Description() string {
+	return "an invalid condition"
+}
+
+func (i invalid) Check(w WebDriver) (bool, error) {
+	return false, i.err
+}
+
+func invalidCondition(message string) Condition {
+	return invalid{err: invalidConditionError{message: message}}
+}
+
+func isVisible(w WebDriver, el Element) (bool, error) {
+	resp, err := executeBuiltIn(w, visibleScriptName, visibleScript, false, "ExecuteScript", el)
+	if err != nil {
+		return false, err
+	}
+
+	var visible bool
+	err = resp.Decode(&visible)
+	if err != nil {
+		return false, newUnmarshallingError(err, "Condition", string(resp.Value))
+	}
+
+	return visible, nil
+}
+
+// hasErrorState checks whether an error was returned by the remote end with
+// the state passed in.
+func hasErrorState(err error, state string) bool {
+	comErr, ok := err.(CommunicationError)
+	return ok && comErr.Response != nil && comErr.Response.State == state
+}
+
+// All is satisfied when every one of the conditions passed in is satisfied.
+// The conditions are checked in order and checking stops at the first which
+// is not satisfied.
+func All(conditions ...Condition) Condition {
+	if !validConditions(conditions) {
+		return invalidCondition("all: invalid conditions argument")
+	}
+
+	return NewCondition(joinDescriptions(conditions, " and "), func(w WebDriver) (bool, error) {
+		for _, c := range conditions {
+			ok, err := c.Check(w)
+			if !ok || err != nil {
+				return false, err
+			}
+		}
+
+		return true, nil
+	})
+}
+
+// Any is satisfied when at least one of the conditions passed in is
+// satisfied. If none of them are, the last error returned by any of them is
+// returned.
+func Any(conditions ...Condition) Condition {
+	if !validConditions(conditions) {
+		return invalidCondition("any: invalid conditions argument")
+	}
+
+	return NewCondition(joinDescriptions(conditions, " or "), func(w WebDriver) (bool, error) {
+		var lastErr error
+		for _, c := range conditions {
+			ok, err := c.Check(w)
+			if _, invalid := err.(invalidConditionError); invalid {
+				return false, err
+			} else if ok && err == nil {
+				return true, nil
+			} else if err != nil {
+				lastErr = err
+			}
+		}
+
+		return false, lastErr
+	})
+}
+
+// Not is satisfied when the condition passed in is not satisfied. If checking
+// the condition returns an error, Not is not satisfied either; use the
+// conditions which handle missing elements (such as UntilElementInvisible)
+// where an element may not exist.
+func Not(c Condition) Condition {
+	if !validConditions([]Condition{c}) {
+		return invalidCondition("not: invalid condition argument")
+	}
+
+	return NewCondition("not "+c.Description(), func(w WebDriver) (bool, error) {
+		ok, err := c.Check(w)
+		if err != nil {
+			return false, err
+		}
+
+		return !ok, nil
+	})
+}
+
+// validConditions checks that there is at least one condition and that
+// none of them are nil or were created with invalid arguments, so that an
+// invalid condition is reported straight away even if it would not be
+// checked until after a condition which keeps failing.
+func validConditions(conditions []Condition) bool {
+	if len(conditions) == 0 {
+		return false
+	}
+	for _, c := range conditions {
+		if _, ok := c.(invalid); ok || c == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func joinDescriptions(conditions []Condition, separator string) string {
+	descriptions := make([]string, len(conditions))
+	for i, c := range conditions {
+		descriptions[i] = c.Description()
+	}
+	if len(descriptions) == 1 {
+		return descriptions[0]
+	}
+
+	return "(" + strings.Join(descriptions, separator) + ")"
+}
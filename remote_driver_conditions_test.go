@@ -0,0 +1,273 @@
+package goselenium
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func setUpConditions(routes map[string]string, errs map[string]error) (*seleniumWebDriver, *routedAPIService) {
+	api := &routedAPIService{routes: routes, errors: errs}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	return d, api
+}
+
+func checkCondition(t *testing.T, d WebDriver, c Condition, expected bool) {
+	t.Helper()
+
+	ok, err := c.Check(d)
+	if ok != expected {
+		t.Errorf("%s: expected %v but got %v (%v)", c.Description(), expected, ok, err)
+	}
+}
+
+func Test_Conditions_PageConditionsAreChecked(t *testing.T) {
+	d, _ := setUpConditions(map[string]string{
+		"/title": `{"state": "success", "value": "Checkout - Shop"}`,
+		"/url":   `{"state": "success", "value": "https://shop.example.com/checkout?step=2"}`,
+	}, nil)
+
+	checkCondition(t, d, UntilTitleIs("Checkout - Shop"), true)
+	checkCondition(t, d, UntilTitleIs("Checkout"), false)
+	checkCondition(t, d, UntilTitleContains("Checkout"), true)
+	checkCondition(t, d, UntilURLContains("/checkout"), true)
+	checkCondition(t, d, UntilURLMatches(regexp.MustCompile(`step=\d$`)), true)
+	checkCondition(t, d, UntilURLMatches(regexp.MustCompile(`/basket`)), false)
+}
+
+func Test_Conditions_ElementConditionsAreChecked(t *testing.T) {
+	d, _ := setUpConditions(map[string]string{
+		"/element":         `{"state": "success", "value": {"element": "e1"}}`,
+		"/elements":        `{"state": "success", "value": [{"element": "e1"}]}`,
+		"/execute":         `{"state": "success", "value": true}`,
+		"/enabled":         `{"state": "success", "value": false}`,
+		"/selected":        `{"state": "success", "value": true}`,
+		"/text":            `{"state": "success", "value": "3 items in your basket"}`,
+		"/attribute/class": `{"state": "success", "value": "btn active"}`,
+		"/css/color":       `{"state": "success", "value": "rgba(0, 0, 0, 1)"}`,
+	}, nil)
+
+	by := ByCSSSelector(".basket")
+	checkCondition(t, d, UntilElementVisible(by), true)
+	checkCondition(t, d, UntilElementInvisible(by), false)
+	checkCondition(t, d, UntilElementClickable(by), false)
+	checkCondition(t, d, UntilElementSelected(by), true)
+	checkCondition(t, d, UntilTextContains(by, "3 items"), true)
+	checkCondition(t, d, UntilTextContains(by, "4 items"), false)
+	checkCondition(t, d, UntilAttributeIs(by, "class", "btn active"), true)
+	checkCondition(t, d, UntilCSSValueIs(by, "color", "rgba(0, 0, 0, 1)"), true)
+}
+
+func Test_Conditions_MissingElementsAreNotSatisfiedButAreInvisible(t *testing.T) {
+	d, _ := setUpConditions(map[string]string{
+		"/elements": `{"state": "success", "value": []}`,
+	}, map[string]error{
+		"/element": &requestError{State: NoSuchElement},
+	})
+
+	by := ByCSSSelector(".spinner")
+	ok, err := UntilElementVisible(by).Check(d)
+	if ok || err == nil || !hasErrorState(err, NoSuchElement) {
+		t.Errorf(correctResponseErrorText)
+	}
+	checkCondition(t, d, UntilElementInvisible(by), true)
+}
+
+func Test_Conditions_InvisibilityRequiresEveryMatchToBeHidden(t *testing.T) {
+	d, api := setUpConditions(map[string]string{
+		"/elements": `{"state": "success", "value": [{"element": "e1"}, {"element": "e2"}]}`,
+		"/execute":  `{"state": "success", "value": false}`,
+	}, nil)
+
+	by := ByCSSSelector(".toast")
+	checkCondition(t, d, UntilElementInvisible(by), true)
+	if len(api.requests) != 3 {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	api.routes["/execute"] = `{"state": "success", "value": true}`
+	checkCondition(t, d, UntilElementInvisible(by), false)
+
+	api.errors = map[string]error{"/execute": &requestError{State: StaleElementReference}}
+	checkCondition(t, d, UntilElementInvisible(by), true)
+}
+
+func Test_Conditions_ElementCountIsChecked(t *testing.T) {
+	d, _ := setUpConditions(map[string]string{
+		"/elements": `{"state": "success", "value": [{"element": "1"}, {"element": "2"}]}`,
+	}, nil)
+
+	checkCondition(t, d, UntilElementCount(ByCSSSelector("li"), 2), true)
+	checkCondition(t, d, UntilElementCount(ByCSSSelector("li"), 3), false)
+}
+
+func Test_Conditions_StalenessIsChecked(t *testing.T) {
+	d, api := setUpConditions(map[string]string{
+		"/execute": `{"state": "success", "value": true}`,
+	}, nil)
+	el := newSeleniumElement("row", d)
+
+	checkCondition(t, d, UntilStale(el), false)
+
+	api.errors = map[string]error{"/execute": &requestError{State: StaleElementReference}}
+	checkCondition(t, d, UntilStale(el), true)
+}
+
+func Test_Conditions_AlertPresenceIsChecked(t *testing.T) {
+	d, api := setUpConditions(nil, map[string]error{
+		"/alert/text": &requestError{State: NoSuchAlert},
+	})
+
+	ok, err := UntilAlertPresent().Check(d)
+	if ok || err != nil {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	api.errors = nil
+	api.routes = map[string]string{"/alert/text": `{"state": "success", "value": "Are you sure?"}`}
+	checkCondition(t, d, UntilAlertPresent(), true)
+}
+
+func Test_Conditions_FrameAvailabilitySwitchesToTheFrame(t *testing.T) {
+	d, api := setUpConditions(map[string]string{
+		"/frame": `{"state": "success"}`,
+	}, nil)
+
+	checkCondition(t, d, UntilFrameAvailable(ByIndex(0)), true)
+	if len(api.requests) != 1 || !strings.HasSuffix(api.requests[0], "/frame") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Conditions_WindowCountIsChecked(t *testing.T) {
+	d, _ := setUpConditions(map[string]string{
+		"/window/handles": `{"state": "success", "value": ["a", "b"]}`,
+	}, nil)
+
+	checkCondition(t, d, UntilWindowCount(2), true)
+	checkCondition(t, d, UntilWindowCount(1), false)
+}
+
+func Test_Conditions_ScriptTruthinessIsChecked(t *testing.T) {
+	d, api := setUpConditions(map[string]string{
+		"/execute": `{"state": "success", "value": false}`,
+	}, nil)
+
+	checkCondition(t, d, UntilScriptTruthy("return window.appReady;"), false)
+	if !strings.Contains(api.bodies[0], "return window.appReady;") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Conditions_DescriptionsAreReadable(t *testing.T) {
+	descriptions := []struct {
+		c        Condition
+		expected string
+	}{
+		{UntilTitleIs("Home"), `title to be "Home"`},
+		{UntilElementVisible(ByCSSSelector(".modal")), "element css selector=.modal to be visible"},
+		{UntilElementCount(ByCSSSelector("li"), 3), "3 elements matching css selector=li"},
+		{UntilWindowCount(2), "2 windows to be open"},
+	}
+	for _, d := range descriptions {
+		if d.c.Description() != d.expected {
+			t.Errorf("expected %q but got %q", d.expected, d.c.Description())
+		}
+	}
+}
+
+func Test_Conditions_InvalidConditionsFailWaitsImmediately(t *testing.T) {
+	d, _ := setUpConditions(nil, nil)
+
+	invalid := []Condition{
+		UntilURLMatches(nil),
+		UntilElementVisible(nil),
+		UntilElementCount(ByCSSSelector("li"), -1),
+		UntilStale(nil),
+		UntilScriptTruthy(" "),
+	}
+	for _, c := range invalid {
+		err := d.WaitContext(context.Background(), c, 0)
+		if err == nil || IsWaitTimeoutError(err) {
+			t.Errorf(argumentErrorText)
+		}
+	}
+}
+
+func Test_Conditions_AllRequiresEveryCondition(t *testing.T) {
+	d, api := setUpConditions(map[string]string{
+		"/title": `{"state": "success", "value": "Checkout"}`,
+		"/url":   `{"state": "success", "value": "https://shop.example.com/basket"}`,
+	}, nil)
+
+	checkCondition(t, d, All(UntilTitleIs("Checkout"), UntilURLContains("/basket")), true)
+
+	api.requests = nil
+	checkCondition(t, d, All(UntilTitleIs("Basket"), UntilURLContains("/basket")), false)
+	if len(api.requests) != 1 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Conditions_AnyRequiresOneCondition(t *testing.T) {
+	d, _ := setUpConditions(map[string]string{
+		"/title": `{"state": "success", "value": "Checkout"}`,
+	}, map[string]error{
+		"/element": &requestError{State: NoSuchElement},
+	})
+
+	checkCondition(t, d, Any(UntilElementVisible(ByCSSSelector(".error")), UntilTitleIs("Checkout")), true)
+
+	ok, err := Any(UntilElementVisible(ByCSSSelector(".error")), UntilTitleIs("Basket")).Check(d)
+	if ok || !hasErrorState(err, NoSuchElement) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Conditions_NotInvertsTheCondition(t *testing.T) {
+	d, _ := setUpConditions(map[string]string{
+		"/title": `{"state": "success", "value": "Loading..."}`,
+	}, nil)
+
+	checkCondition(t, d, Not(UntilTitleIs("Loading...")), false)
+	checkCondition(t, d, Not(UntilTitleContains("Checkout")), true)
+}
+
+func Test_Conditions_CombinatorsAcceptUntilFunctions(t *testing.T) {
+	d, _ := setUpConditions(nil, nil)
+
+	yes := Until(func(w WebDriver) bool { return true })
+	no := Until(func(w WebDriver) bool { return false })
+
+	checkCondition(t, d, All(yes, Not(no)), true)
+	checkCondition(t, d, Any(no, no), false)
+}
+
+func Test_Conditions_CombinatorsAreDescribed(t *testing.T) {
+	c := Any(UntilTitleIs("A"), All(UntilTitleIs("B"), Not(UntilWindowCount(1))))
+
+	expected := `(title to be "A" or (title to be "B" and not 1 windows to be open))`
+	if c.Description() != expected {
+		t.Errorf("expected %q but got %q", expected, c.Description())
+	}
+}
+
+func Test_Conditions_InvalidCombinatorsFailWaitsImmediately(t *testing.T) {
+	d, _ := setUpConditions(nil, nil)
+
+	invalid := []Condition{
+		All(),
+		Any(UntilTitleIs("A"), nil),
+		Not(nil),
+		All(UntilTitleIs("A"), UntilStale(nil)),
+	}
+	for _, c := range invalid {
+		err := d.WaitContext(context.Background(), c, 0)
+		if err == nil || IsWaitTimeoutError(err) {
+			t.Errorf(argumentErrorText)
+		}
+	}
+}
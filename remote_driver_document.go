@@ -1,6 +1,10 @@
 package goselenium
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
 
 // PageSourceResponse is the response returned from calling the PageSource
 // method.
@@ -11,9 +15,163 @@ type PageSourceResponse struct {
 
 // ExecuteScriptResponse is the response returned from calling the ExecuteScript
 // method.
+//
+// Response is the value returned by the script if it is a string, or its JSON
+// otherwise. Value is the JSON of the value returned by the script, which can
+// be decoded with Decode; elements that are returned can be retrieved with
+// Element, Elements or Result.
 type ExecuteScriptResponse struct {
 	State    string
 	Response string
+	Value    json.RawMessage
+
+	wd *seleniumWebDriver
+}
+
+func newExecuteScriptResponse(resp *rawValueResponse, wd *seleniumWebDriver) *ExecuteScriptResponse {
+	r := &ExecuteScriptResponse{State: resp.State, Value: resp.Value, wd: wd}
+
+	err := json.Unmarshal(resp.Value, &r.Response)
+	if err != nil && string(resp.Value) != "null" {
+		r.Response = string(resp.Value)
+	}
+
+	return r
+}
+
+// withValue returns a copy of the response with a different value, which is
+// used when the script's value has been wrapped (i.e. by Eval).
+func (e *ExecuteScriptResponse) withValue(value json.RawMessage) *ExecuteScriptResponse {
+	return newExecuteScriptResponse(&rawValueResponse{State: e.State, Value: value}, e.wd)
+}
+
+// Decode unmarshals the value returned by the script into v, in the same way
+// as json.Unmarshal. Elements are decoded as their JSON representation; use
+// Element, Elements or Result to retrieve them as Elements.
+func (e *ExecuteScriptResponse) Decode(v interface{}) error {
+	if len(e.Value) == 0 {
+		return json.Unmarshal([]byte("null"), v)
+	}
+
+	return json.Unmarshal(e.Value, v)
+}
+
+// Element returns the element returned by the script. An error is returned
+// if the script did not return an element.
+func (e *ExecuteScriptResponse) Element() (Element, error) {
+	result, err := e.Result()
+	if err != nil {
+		return nil, err
+	}
+
+	el, ok := result.(Element)
+	if !ok {
+		return nil, errors.New("executescript: result is not an element")
+	}
+
+	return el, nil
+}
+
+// Elements returns the elements returned by the script, which must have
+// returned an array (or NodeList) of elements. If it returned null, no
+// elements are returned.
+func (e *ExecuteScriptResponse) Elements() ([]Element, error) {
+	result, err := e.Result()
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return []Element{}, nil
+	}
+
+	values, ok := result.([]interface{})
+	if !ok {
+		return nil, errors.New("executescript: result is not an array of elements")
+	}
+
+	elements := make([]Element, len(values))
+	for i := range values {
+		el, ok := values[i].(Element)
+		if !ok {
+			return nil, errors.New("executescript: result is not an array of elements")
+		}
+		elements[i] = el
+	}
+
+	return elements, nil
+}
+
+// Result returns the value returned by the script as it would be decoded into
+// an interface{} by json.Unmarshal, except that elements (including those
+// within arrays and objects) are returned as Elements.
+func (e *ExecuteScriptResponse) Result() (interface{}, error) {
+	var result interface{}
+	err := e.Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.convertElements(result), nil
+}
+
+func (e *ExecuteScriptResponse) convertElements(v interface{}) interface{} {
+	switch value := v.(type) {
+	case []interface{}:
+		for i := range value {
+			value[i] = e.convertElements(value[i])
+		}
+	case map[string]interface{}:
+		if id, ok := elementReferenceID(value); ok {
+			return newSeleniumElement(id, e.wd)
+		}
+		for k := range value {
+			value[k] = e.convertElements(value[k])
+		}
+	}
+
+	return v
+}
+
+// elementReferenceID returns the ID of an element if the object passed in is
+// the JSON representation of one.
+func elementReferenceID(m map[string]interface{}) (string, bool) {
+	for _, key := range []string{webElementIdentifier, "ELEMENT"} {
+		id, ok := m[key].(string)
+		if ok && len(m) <= 2 {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+// scriptArgument converts an argument passed to a script so that elements
+// (including those within slices and maps) are sent as element references.
+func scriptArgument(arg interface{}) interface{} {
+	switch value := arg.(type) {
+	case Element:
+		return elementReference(value.ID())
+	case []Element:
+		converted := make([]interface{}, len(value))
+		for i := range value {
+			converted[i] = scriptArgument(value[i])
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(value))
+		for i := range value {
+			converted[i] = scriptArgument(value[i])
+		}
+		return converted
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(value))
+		for k := range value {
+			converted[k] = scriptArgument(value[k])
+		}
+		return converted
+	}
+
+	return arg
 }
 
 func (s *seleniumWebDriver) PageSource() (*PageSourceResponse, error) {
@@ -38,22 +196,18 @@ func (s *seleniumWebDriver) PageSource() (*PageSourceResponse, error) {
 	return &PageSourceResponse{State: resp.State, Source: resp.Value}, nil
 }
 
-func (s *seleniumWebDriver) ExecuteScript(script string) (*ExecuteScriptResponse, error) {
+func (s *seleniumWebDriver) ExecuteScript(script string, args ...interface{}) (*ExecuteScriptResponse, error) {
 	if len(s.sessionID) == 0 {
 		return nil, newSessionIDError("ExecuteScript")
 	}
 
-	url := fmt.Sprintf("%s/session/%s/execute", s.seleniumURL, s.sessionID)
-
-	return s.scriptRequest(script, url, "ExecuteScript")
+	return s.scriptRequest(script, args, false, "ExecuteScript")
 }
 
-func (s *seleniumWebDriver) ExecuteScriptAsync(script string) (*ExecuteScriptResponse, error) {
+func (s *seleniumWebDriver) ExecuteScriptAsync(script string, args ...interface{}) (*ExecuteScriptResponse, error) {
 	if len(s.sessionID) == 0 {
 		return nil, newSessionIDError("ExecuteScriptAsync")
 	}
 
-	url := fmt.Sprintf("%s/session/%s/execute_async", s.seleniumURL, s.sessionID)
-
-	return s.scriptRequest(script, url, "ExecuteScriptAsync")
+	return s.scriptRequest(script, args, true, "ExecuteScriptAsync")
 }
@@ -1,7 +1,9 @@
 package goselenium
 
 import (
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -199,3 +201,151 @@ func Test_CommandExecuteScriptAsync_ResultIsReturnedSuccessfully(t *testing.T) {
 		t.Errorf(correctResponseErrorText)
 	}
 }
+
+func Test_CommandExecuteScript_ArgumentsAreSerialized(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success", "value": null}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	el := newSeleniumElement("abc", d)
+	_, err := d.ExecuteScript("return arguments;", 1, "two", el, []Element{el}, map[string]interface{}{"el": el})
+	if err != nil {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	var body struct {
+		Args []interface{} `json:"args"`
+	}
+	json.Unmarshal([]byte(api.lastBody), &body)
+	if len(body.Args) != 5 || body.Args[0] != float64(1) || body.Args[1] != "two" {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	ref := body.Args[2].(map[string]interface{})
+	list := body.Args[3].([]interface{})[0].(map[string]interface{})
+	nested := body.Args[4].(map[string]interface{})["el"].(map[string]interface{})
+	if ref[webElementIdentifier] != "abc" || list[webElementIdentifier] != "abc" || nested[webElementIdentifier] != "abc" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_CommandExecuteScript_NoArgumentsAreSentAsAnEmptyArray(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success", "value": null}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.ExecuteScript("return 1;")
+	if err != nil || !strings.Contains(api.lastBody, `"args":[]`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_CommandExecuteScript_NonStringResultsCanBeDecoded(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success", "value": {"count": 3, "names": ["a", "b"]}}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.ExecuteScript("return {count: 3, names: ['a', 'b']};")
+	if err != nil || resp.Response != `{"count": 3, "names": ["a", "b"]}` {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	var result struct {
+		Count int
+		Names []string
+	}
+	err = resp.Decode(&result)
+	if err != nil || result.Count != 3 || len(result.Names) != 2 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_CommandExecuteScript_StringResultsAreUnquoted(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success", "value": "test"}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.ExecuteScript("return 'test';")
+	if err != nil || resp.Response != "test" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_CommandExecuteScript_ElementsAreReturned(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success", "value": {
+			"el": {"element-6066-11e4-a52e-4f735466cecf": "a"},
+			"els": [{"ELEMENT": "b"}, {"element-6066-11e4-a52e-4f735466cecf": "c"}]
+		}}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.ExecuteScript("return {el: a, els: [b, c]};")
+	if err != nil {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	result, err := resp.Result()
+	if err != nil {
+		t.Fatalf(correctResponseErrorText)
+	}
+	m := result.(map[string]interface{})
+	if m["el"].(Element).ID() != "a" || m["els"].([]interface{})[0].(Element).ID() != "b" {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	_, err = resp.Element()
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_CommandExecuteScript_ElementAndElementsAreReturned(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success", "value": [{"element-6066-11e4-a52e-4f735466cecf": "a"}, {"ELEMENT": "b"}]}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.ExecuteScript("return document.querySelectorAll('a');")
+	if err != nil {
+		t.Fatalf(correctResponseErrorText)
+	}
+	els, err := resp.Elements()
+	if err != nil || len(els) != 2 || els[1].ID() != "b" {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	api.jsonToReturn = `{"state": "success", "value": {"element-6066-11e4-a52e-4f735466cecf": "a"}}`
+	resp, err = d.ExecuteScript("return document.body;")
+	if err != nil {
+		t.Fatalf(correctResponseErrorText)
+	}
+	el, err := resp.Element()
+	if err != nil || el.ID() != "a" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_CommandExecuteScriptAsync_ArgumentsPrecedeCallback(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success", "value": 42}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.ExecuteScriptAsync("arguments[1](arguments[0] * 2);", 21)
+	if err != nil || resp.Response != "42" || !strings.HasSuffix(api.lastURL, "/execute_async") ||
+		!strings.Contains(api.lastBody, `"args":[21]`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
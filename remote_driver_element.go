@@ -14,8 +14,37 @@ type findElementsResponse struct {
 	E []element `json:"value"`
 }
 
+// webElementIdentifier is the key the W3C specification uses to identify a
+// web element reference within JSON objects.
+const webElementIdentifier = "element-6066-11e4-a52e-4f735466cecf"
+
 type element struct {
-	ID string `json:"element"`
+	ID    string `json:"element"`
+	W3CID string `json:"element-6066-11e4-a52e-4f735466cecf"`
+}
+
+func (e element) id() string {
+	if e.W3CID != "" {
+		return e.W3CID
+	}
+
+	return e.ID
+}
+
+// elementReference creates the JSON representation of an element so that it
+// can be passed as an argument to the remote end.
+func elementReference(id string) map[string]string {
+	return map[string]string{
+		webElementIdentifier: id,
+		"ELEMENT":            id,
+	}
+}
+
+// finder is implemented by By values that locate elements on the client
+// (usually by injecting a script) rather than forwarding a strategy to the
+// remote end. The root is nil when the whole document is being searched.
+type finder interface {
+	find(s *seleniumWebDriver, root *seleniumElement) ([]Element, error)
 }
 
 func (s *seleniumWebDriver) FindElement(by By) (Element, error) {
@@ -25,6 +54,17 @@ func (s *seleniumWebDriver) FindElement(by By) (Element, error) {
 	if len(s.sessionID) == 0 {
 		return nil, newSessionIDError("FindElement")
 	}
+	if _, ok := clientFinder(by); ok {
+		elements, err := s.findElements(by, nil, "FindElement")
+		if err != nil {
+			return nil, err
+		}
+		if len(elements) == 0 {
+			return nil, newNoSuchElementError("FindElement", s.seleniumURL, by)
+		}
+
+		return elements[0], nil
+	}
 
 	var response findElementResponse
 	var err error
@@ -46,7 +86,8 @@ func (s *seleniumWebDriver) FindElement(by By) (Element, error) {
 		return nil, newUnmarshallingError(err, "FindElement", string(resp))
 	}
 
-	el := newSeleniumElement(response.E.ID, s)
+	el := newSeleniumElement(response.E.id(), s)
+	el.locator = &elementLocator{by: by, single: true}
 	return el, nil
 }
 
@@ -58,16 +99,37 @@ func (s *seleniumWebDriver) FindElements(by By) ([]Element, error) {
 		return nil, newSessionIDError("FindElements")
 	}
 
+	return s.findElements(by, nil, "FindElements")
+}
+
+// findElements locates all elements matching by. If root is not nil, only
+// its descendants will be searched.
+func (s *seleniumWebDriver) findElements(by By, root *seleniumElement, method string) ([]Element, error) {
+	if f, ok := clientFinder(by); ok {
+		elements, err := f.find(s, root)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := by.(elementBy); !ok {
+			rememberLocator(elements, by, root)
+		}
+
+		return elements, nil
+	}
+
 	var response findElementsResponse
 	var err error
 
 	url := fmt.Sprintf("%s/session/%s/elements", s.seleniumURL, s.sessionID)
+	if root != nil {
+		url = fmt.Sprintf("%s/session/%s/element/%s/elements", s.seleniumURL, s.sessionID, root.ID())
+	}
 
 	resp, err := s.elementRequest(&elRequest{
 		url:           url,
 		by:            by,
 		method:        "POST",
-		callingMethod: "FindElements",
+		callingMethod: method,
 	})
 	if err != nil {
 		return nil, err
@@ -75,13 +137,14 @@ func (s *seleniumWebDriver) FindElements(by By) ([]Element, error) {
 
 	err = json.Unmarshal(resp, &response)
 	if err != nil {
-		return nil, newUnmarshallingError(err, "FindElements", string(resp))
+		return nil, newUnmarshallingError(err, method, string(resp))
 	}
 
 	elements := make([]Element, len(response.E))
 	for i := range response.E {
-		elements[i] = newSeleniumElement(response.E[i].ID, s)
+		elements[i] = newSeleniumElement(response.E[i].id(), s)
 	}
+	rememberLocator(elements, by, root)
 
 	return elements, nil
 }
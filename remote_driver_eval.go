@@ -0,0 +1,113 @@
+package goselenium
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// scriptPlaceholder is replaced by the script being wrapped within the scripts
+// used by Eval, ScriptRegistry and UntilScriptTruthy.
+const scriptPlaceholder = "/* script */"
+
+// evalScript runs a script as the body of a function and passes the value it
+// returns (once settled, if it is a Promise) or the exception that it throws
+// to the async callback.
+const evalScript = `
+var done = arguments[arguments.length - 1];
+var args = Array.prototype.slice.call(arguments, 0, arguments.length - 1);
+var fail = function(e) {
+	if (e instanceof Error) {
+		done({ok: false, error: {name: e.name, message: e.message, stack: e.stack || ''}});
+	} else {
+		done({ok: false, error: {name: 'Error', message: String(e), stack: ''}});
+	}
+};
+try {
+	Promise.resolve((function() {
+/* script */
+	}).apply(null, args)).then(function(value) {
+		done({ok: true, value: value === undefined ? null : value});
+	}, fail);
+} catch (e) {
+	fail(e);
+}
+`
+
+// evalResult is the value that evalScript passes to the async callback.
+type evalResult struct {
+	OK    bool            `json:"ok"`
+	Value json.RawMessage `json:"value"`
+	Error *struct {
+		Name    string `json:"name"`
+		Message string `json:"message"`
+		Stack   string `json:"stack"`
+	} `json:"error"`
+}
+
+// Eval executes a script on the currently active page and decodes the value
+// that it returns into T. As with ExecuteScript, the script is the body of a
+// function and its arguments are available through the 'arguments' array.
+// If the script returns a Promise, the value that it resolves to is used; the
+// script timeout of the session applies whilst waiting for it.
+//
+// T can be any type that the value can be unmarshalled into with
+// encoding/json. Elements can be returned by using Element, []Element or
+// interface{} (in which case elements within arrays and objects are returned
+// as Elements too) as T.
+//
+// If the script throws an exception, or the Promise is rejected, a
+// JavascriptExecutionError is returned.
+//
+//	type item struct {
+//		Name  string
+//		Price float64
+//	}
+//	items, err := goselenium.Eval[[]item](d, `
+//		return fetch(arguments[0]).then(function(r) { return r.json(); });
+//	`, "/api/items")
+func Eval[T any](d WebDriver, script string, args ...interface{}) (T, error) {
+	var result T
+	if d == nil {
+		return result, errors.New("eval: invalid driver argument")
+	}
+
+	resp, err := d.ExecuteScriptAsync(strings.Replace(evalScript, scriptPlaceholder, script, 1), args...)
+	if err != nil {
+		// Syntax errors in the script are reported by the remote end.
+		comErr, ok := err.(CommunicationError)
+		if ok && comErr.Response != nil && comErr.Response.State == JavascriptError {
+			return result, newJavascriptExecutionError("Eval", "", comErr.Response.Message, "")
+		}
+		return result, err
+	}
+
+	var outcome evalResult
+	err = resp.Decode(&outcome)
+	if err != nil {
+		return result, newUnmarshallingError(err, "Eval", string(resp.Value))
+	}
+	if !outcome.OK {
+		if outcome.Error == nil {
+			return result, newUnmarshallingError(errors.New("result is missing"), "Eval", string(resp.Value))
+		}
+		return result, newJavascriptExecutionError("Eval", outcome.Error.Name, outcome.Error.Message, outcome.Error.Stack)
+	}
+
+	value := resp.withValue(outcome.Value)
+	switch target := interface{}(&result).(type) {
+	case *Element:
+		*target, err = value.Element()
+	case *[]Element:
+		*target, err = value.Elements()
+	case *interface{}:
+		*target, err = value.Result()
+	default:
+		err = value.Decode(&result)
+	}
+	if err != nil {
+		return result, newUnmarshallingError(err, "Eval", string(outcome.Value))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,134 @@
+package goselenium
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func setUpEvalDriver(json string, err error) (*seleniumWebDriver, *testableAPIService) {
+	api := &testableAPIService{
+		jsonToReturn:  json,
+		errorToReturn: err,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	return d, api
+}
+
+func Test_Eval_InvalidDriverResultsInError(t *testing.T) {
+	_, err := Eval[int](nil, "return 1;")
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_Eval_ScriptIsWrappedAndExecutedAsynchronously(t *testing.T) {
+	d, api := setUpEvalDriver(`{"state": "success", "value": {"ok": true, "value": 2}}`, nil)
+
+	result, err := Eval[int](d, "return arguments[0] + 1;", 1)
+	if err != nil || result != 2 {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if !strings.HasSuffix(api.lastURL, "/execute_async") || !strings.Contains(api.lastBody, "return arguments[0] + 1;") ||
+		!strings.Contains(api.lastBody, "Promise.resolve") || !strings.Contains(api.lastBody, `"args":[1]`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Eval_ResultsAreDecodedIntoStructsMapsAndSlices(t *testing.T) {
+	d, _ := setUpEvalDriver(`{"state": "success", "value": {"ok": true, "value": [{"name": "a", "price": 1.5}]}}`, nil)
+
+	type item struct {
+		Name  string
+		Price float64
+	}
+	items, err := Eval[[]item](d, "return items;")
+	if err != nil || len(items) != 1 || items[0].Name != "a" || items[0].Price != 1.5 {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	maps, err := Eval[[]map[string]interface{}](d, "return items;")
+	if err != nil || len(maps) != 1 || maps[0]["name"] != "a" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Eval_ElementsAreReturned(t *testing.T) {
+	d, api := setUpEvalDriver(`{"state": "success", "value": {"ok": true, "value": {"element-6066-11e4-a52e-4f735466cecf": "a"}}}`, nil)
+
+	el, err := Eval[Element](d, "return document.body;")
+	if err != nil || el.ID() != "a" {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	api.jsonToReturn = `{"state": "success", "value": {"ok": true, "value": [{"element-6066-11e4-a52e-4f735466cecf": "b"}]}}`
+	els, err := Eval[[]Element](d, "return document.links;")
+	if err != nil || len(els) != 1 || els[0].ID() != "b" {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	result, err := Eval[interface{}](d, "return document.links;")
+	if err != nil || result.([]interface{})[0].(Element).ID() != "b" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Eval_UndefinedResultsInZeroValue(t *testing.T) {
+	d, _ := setUpEvalDriver(`{"state": "success", "value": {"ok": true, "value": null}}`, nil)
+
+	result, err := Eval[string](d, "console.log('hello');")
+	if err != nil || result != "" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Eval_ExceptionsResultInJavascriptExecutionError(t *testing.T) {
+	d, _ := setUpEvalDriver(`{"state": "success", "value": {"ok": false, "error": {
+		"name": "TypeError",
+		"message": "x is undefined",
+		"stack": "TypeError: x is undefined\n    at <anonymous>:3:1"
+	}}}`, nil)
+
+	_, err := Eval[int](d, "return x.y;")
+	if !IsJavascriptExecutionError(err) {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	jsErr := err.(JavascriptExecutionError)
+	if jsErr.Name != "TypeError" || jsErr.Message != "x is undefined" || !strings.Contains(jsErr.Stack, "<anonymous>:3:1") ||
+		!strings.Contains(err.Error(), "<anonymous>:3:1") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Eval_SyntaxErrorsResultInJavascriptExecutionError(t *testing.T) {
+	d, _ := setUpEvalDriver("", &requestError{
+		State: JavascriptError,
+		Value: requestErrorValue{Message: "SyntaxError: Unexpected token"},
+	})
+
+	_, err := Eval[int](d, "return {;")
+	if !IsJavascriptExecutionError(err) || !strings.Contains(err.(JavascriptExecutionError).Message, "Unexpected token") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Eval_CommunicationErrorIsReturned(t *testing.T) {
+	d, _ := setUpEvalDriver("", errors.New("An error :<"))
+
+	_, err := Eval[int](d, "return 1;")
+	if !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_Eval_UnexpectedTypeResultsInUnmarshallingError(t *testing.T) {
+	d, _ := setUpEvalDriver(`{"state": "success", "value": {"ok": true, "value": "text"}}`, nil)
+
+	_, err := Eval[int](d, "return 'text';")
+	if !IsUnmarshallingError(err) {
+		t.Errorf(unmarshallingErrorText)
+	}
+}
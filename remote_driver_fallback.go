@@ -0,0 +1,204 @@
+package goselenium
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"sync"
+)
+
+// FallbackBy is a By implementation which tries a list of locators in order
+// and uses the first one that finds at least one element. It is created by
+// calling ByFallback.
+//
+// The outcome of each search can be observed with OnResolve, and searches that
+// needed to fall back (or found nothing) can be collected in a HealingReport
+// so that broken locators can be updated in bulk.
+type FallbackBy struct {
+	locators []By
+	hook     func(FallbackResult)
+	report   *HealingReport
+}
+
+// FallbackResult describes the outcome of searching with a FallbackBy.
+type FallbackResult struct {
+	// Locators are all of the locators of the FallbackBy, in order.
+	Locators []By
+
+	// Matched is the locator that found elements, or nil if none did.
+	Matched By
+
+	// Index is the index of Matched within Locators, or -1 if none matched.
+	Index int
+
+	// Failures are the locators that were tried before Matched (or all of
+	// them if none matched).
+	Failures []FallbackFailure
+}
+
+// Healed returns whether a locator other than the first was used.
+func (f FallbackResult) Healed() bool {
+	return f.Index > 0
+}
+
+// FallbackFailure is a locator that did not find any elements. Err is the
+// error returned whilst searching, or nil if the search simply found nothing.
+type FallbackFailure struct {
+	By  By
+	Err error
+}
+
+// ByFallback creates a locator that tries each of the locators passed in, in
+// order, until one finds at least one element.
+func ByFallback(locators ...By) *FallbackBy {
+	return &FallbackBy{locators: locators}
+}
+
+// OnResolve sets a hook which is called with the result of every search.
+func (f *FallbackBy) OnResolve(hook func(FallbackResult)) *FallbackBy {
+	return &FallbackBy{locators: f.locators, hook: hook, report: f.report}
+}
+
+// RecordTo records every search that did not match with the first locator in
+// the report passed in.
+func (f *FallbackBy) RecordTo(report *HealingReport) *FallbackBy {
+	return &FallbackBy{locators: f.locators, hook: f.hook, report: report}
+}
+
+// Type returns the type of the fallback locator.
+func (f *FallbackBy) Type() string {
+	return "fallback"
+}
+
+// Value returns a description of each of the locators.
+func (f *FallbackBy) Value() interface{} {
+	parts := make([]string, len(f.locators))
+	for i := range f.locators {
+		parts[i] = describeBy(f.locators[i])
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func (f *FallbackBy) find(s *seleniumWebDriver, root *seleniumElement) ([]Element, error) {
+	result := FallbackResult{Locators: f.locators, Index: -1}
+
+	var found []Element
+	var lastErr error
+	for i, l := range f.locators {
+		elements, err := s.findElements(l, root, "FindElements")
+		if err == nil && len(elements) > 0 {
+			result.Matched = l
+			result.Index = i
+			found = elements
+			break
+		}
+
+		result.Failures = append(result.Failures, FallbackFailure{By: l, Err: err})
+		lastErr = err
+	}
+
+	if f.hook != nil {
+		f.hook(result)
+	}
+	if f.report != nil && result.Index != 0 {
+		f.report.Record(result)
+	}
+
+	// If every locator failed with an error (rather than simply finding
+	// nothing), the problem is unlikely to be the locators themselves.
+	if result.Index == -1 && lastErr != nil {
+		allFailed := true
+		for _, failure := range result.Failures {
+			allFailed = allFailed && failure.Err != nil
+		}
+		if allFailed {
+			return nil, lastErr
+		}
+	}
+
+	return found, nil
+}
+
+// HealingReport collects the results of fallback locators which did not match
+// with their first locator. It is safe for concurrent use.
+type HealingReport struct {
+	path    string
+	mu      sync.Mutex
+	entries []*HealingEntry
+}
+
+// HealingEntry is a single entry within a HealingReport. Entries are unique
+// by their primary and matched locators.
+type HealingEntry struct {
+	Primary string   `json:"primary"`
+	Matched string   `json:"matched"`
+	Failed  []string `json:"failed"`
+	Count   int      `json:"count"`
+}
+
+// NewHealingReport creates a report which will be written to path as JSON
+// when Save is called.
+func NewHealingReport(path string) *HealingReport {
+	return &HealingReport{path: path}
+}
+
+// Record adds a result to the report. Results which matched with their first
+// locator are ignored.
+func (h *HealingReport) Record(result FallbackResult) {
+	if result.Index == 0 || len(result.Locators) == 0 {
+		return
+	}
+
+	primary := describeBy(result.Locators[0])
+	var matched string
+	if result.Matched != nil {
+		matched = describeBy(result.Matched)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, e := range h.entries {
+		if e.Primary == primary && e.Matched == matched {
+			e.Count++
+			return
+		}
+	}
+
+	failed := make([]string, len(result.Failures))
+	for i := range result.Failures {
+		failed[i] = describeBy(result.Failures[i].By)
+	}
+	h.entries = append(h.entries, &HealingEntry{
+		Primary: primary,
+		Matched: matched,
+		Failed:  failed,
+		Count:   1,
+	})
+}
+
+// Entries returns a copy of the entries that have been recorded so far.
+func (h *HealingReport) Entries() []HealingEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entries := make([]HealingEntry, len(h.entries))
+	for i := range h.entries {
+		entries[i] = *h.entries[i]
+	}
+
+	return entries
+}
+
+// Save writes the report to its path as JSON, replacing any existing file.
+func (h *HealingReport) Save() error {
+	entries := h.Entries()
+
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return newMarshallingError(err, "Save", entries)
+	}
+
+	return ioutil.WriteFile(h.path, b, 0644)
+}
@@ -0,0 +1,124 @@
+package goselenium
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// staticBy is a client side By which returns a fixed result.
+type staticBy struct {
+	name     string
+	elements []Element
+	err      error
+}
+
+func (s staticBy) Type() string {
+	return "static"
+}
+
+func (s staticBy) Value() interface{} {
+	return s.name
+}
+
+func (s staticBy) find(wd *seleniumWebDriver, root *seleniumElement) ([]Element, error) {
+	return s.elements, s.err
+}
+
+func setUpFallbackDriver() *seleniumWebDriver {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	d.sessionID = "12345"
+	return d
+}
+
+func Test_FallbackBy_FirstMatchingLocatorIsUsed(t *testing.T) {
+	d := setUpFallbackDriver()
+
+	var result FallbackResult
+	by := ByFallback(
+		staticBy{name: "broken"},
+		staticBy{name: "invalid", err: errors.New("invalid selector")},
+		staticBy{name: "working", elements: []Element{newSeleniumElement("1", d)}},
+		staticBy{name: "unused", elements: []Element{newSeleniumElement("2", d)}},
+	).OnResolve(func(r FallbackResult) {
+		result = r
+	})
+
+	el, err := d.FindElement(by)
+	if err != nil || el.ID() != "1" {
+		t.Errorf(correctResponseErrorText)
+	}
+	if !result.Healed() || result.Index != 2 || result.Matched.Value() != "working" ||
+		len(result.Failures) != 2 || result.Failures[0].Err != nil || result.Failures[1].Err == nil {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_FallbackBy_NoSuchElementIsReturnedWhenNothingMatches(t *testing.T) {
+	d := setUpFallbackDriver()
+
+	_, err := d.FindElement(ByFallback(staticBy{name: "a"}, staticBy{name: "b"}))
+	comErr, ok := err.(CommunicationError)
+	if !ok || comErr.Response.State != NoSuchElement {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_FallbackBy_ErrorIsReturnedWhenEveryLocatorFails(t *testing.T) {
+	d := setUpFallbackDriver()
+
+	commErr := newCommunicationError(errors.New("An error :<"), "FindElements", "", nil)
+	_, err := d.FindElements(ByFallback(staticBy{name: "a", err: commErr}, staticBy{name: "b", err: commErr}))
+	if err == nil || !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_FallbackBy_ValueDescribesEachLocator(t *testing.T) {
+	by := ByFallback(ByID("login"), ByXPath("//button"))
+	if by.Type() != "fallback" || by.Value().(string) != "css selector=#login, xpath=//button" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_HealingReport_HealedResultsAreRecordedAndSaved(t *testing.T) {
+	d := setUpFallbackDriver()
+
+	dir, err := ioutil.TempDir("", "healing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	report := NewHealingReport(filepath.Join(dir, "report.json"))
+	healed := ByFallback(
+		staticBy{name: "broken"},
+		staticBy{name: "working", elements: []Element{newSeleniumElement("1", d)}},
+	).RecordTo(report)
+	primary := ByFallback(
+		staticBy{name: "working", elements: []Element{newSeleniumElement("1", d)}},
+	).RecordTo(report)
+
+	d.FindElement(healed)
+	d.FindElement(healed)
+	d.FindElement(primary)
+
+	entries := report.Entries()
+	if len(entries) != 1 || entries[0].Primary != "static=broken" ||
+		entries[0].Matched != "static=working" || entries[0].Count != 2 {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	if err := report.Save(); err != nil {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "report.json"))
+	var saved []HealingEntry
+	if err != nil || json.Unmarshal(b, &saved) != nil || len(saved) != 1 || saved[0].Failed[0] != "static=broken" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
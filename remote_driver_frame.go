@@ -0,0 +1,358 @@
+package goselenium
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ByFrameName finds a frame or iframe element by its name or id attribute,
+// which is how frames were identified before the W3C specification. It is
+// translated into an escaped CSS selector so that it can be passed to
+// SwitchToFrame (or FindElement) with any remote end.
+func ByFrameName(name string) By {
+	escaped := cssEscape(name)
+
+	return by{
+		t: "css selector",
+		value: fmt.Sprintf(`iframe[name="%[1]s"], iframe[id="%[1]s"], frame[name="%[1]s"], frame[id="%[1]s"]`,
+			escaped),
+	}
+}
+
+func (s *seleniumWebDriver) SwitchToDefaultContent() (*SwitchToFrameResponse, error) {
+	if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("SwitchToDefaultContent")
+	}
+
+	return s.switchToFrame(nil)
+}
+
+func (s *seleniumWebDriver) WithinFrame(by By, f func() error) error {
+	if f == nil {
+		return errors.New("withinframe: invalid f argument")
+	}
+
+	previous := s.currentFramePath()
+
+	_, err := s.SwitchToFrame(by)
+	if err != nil {
+		return err
+	}
+
+	err = f()
+
+	restoreErr := s.restoreFrame(previous, err != nil)
+	if err != nil {
+		return err
+	}
+
+	return restoreErr
+}
+
+func (s *seleniumWebDriver) WithinWindow(handle string, f func() error) error {
+	if f == nil {
+		return errors.New("withinwindow: invalid f argument")
+	}
+
+	previous, err := s.WindowHandle()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.SwitchToWindow(handle)
+	if err != nil {
+		return err
+	}
+
+	err = f()
+
+	_, restoreErr := s.SwitchToWindow(previous.Handle)
+	if err != nil {
+		return err
+	}
+
+	return restoreErr
+}
+
+// restoreFrame switches back to the frame that was current before
+// WithinFrame switched into a child frame of it. The parent frame is switched
+// to if the driver is still within that child frame; otherwise the previous
+// frame is re-entered by its path. If the path is not known (because a frame
+// was switched to by its element), the parent frame is switched to, unless f
+// failed (in which case it may have left the driver in any frame) and the top
+// level browsing context is switched to instead.
+func (s *seleniumWebDriver) restoreFrame(previous FramePath, failed bool) error {
+	current := s.currentFramePath()
+
+	var err error
+	switch {
+	case len(current) == len(previous)+1 && current[:len(previous)].equal(previous):
+		_, err = s.SwitchToParentFrame()
+	case previous.known():
+		_, err = s.SwitchToFramePath(previous)
+	case failed:
+		_, err = s.SwitchToDefaultContent()
+	default:
+		_, err = s.SwitchToParentFrame()
+	}
+
+	return err
+}
+
+// currentFramePath returns the path of the frame which the driver last
+// switched to.
+func (s *seleniumWebDriver) currentFramePath() FramePath {
+	s.frameMu.Lock()
+	defer s.frameMu.Unlock()
+
+	return append(FramePath{}, s.framePath...)
+}
+
+func (s *seleniumWebDriver) setFramePath(path FramePath) {
+	s.frameMu.Lock()
+	defer s.frameMu.Unlock()
+
+	s.framePath = path
+}
+
+// enteredFrame records that the driver switched to the frame identified by
+// id (see switchToFrame).
+func (s *seleniumWebDriver) enteredFrame(id interface{}) {
+	s.frameMu.Lock()
+	defer s.frameMu.Unlock()
+
+	switch i := id.(type) {
+	case nil:
+		s.framePath = FramePath{}
+	case int:
+		s.framePath = append(s.framePath, i)
+	case uint:
+		s.framePath = append(s.framePath, int(i))
+	default:
+		s.framePath = append(s.framePath, -1)
+	}
+}
+
+// leftFrame records that the driver switched to the parent frame.
+func (s *seleniumWebDriver) leftFrame() {
+	s.frameMu.Lock()
+	defer s.frameMu.Unlock()
+
+	if len(s.framePath) > 0 {
+		s.framePath = s.framePath[:len(s.framePath)-1]
+	}
+}
+
+// FramePath identifies a frame by the index of each frame that must be
+// switched to, in turn, from the top level browsing context. An empty path is
+// the top level browsing context itself.
+type FramePath []int
+
+// known checks whether every frame within the path was switched to by its
+// index, in which case it can be passed to SwitchToFramePath.
+func (p FramePath) known() bool {
+	for _, i := range p {
+		if i < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p FramePath) equal(other FramePath) bool {
+	if len(p) != len(other) {
+		return false
+	}
+	for i := range p {
+		if p[i] != other[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Frame is a frame (or iframe) within the frame tree of a page. The root of
+// the tree returned by FrameTree is the top level browsing context, which has
+// an empty Path and no Name, ID or Src.
+type Frame struct {
+	Path     FramePath `json:"-"`
+	Name     string    `json:"name"`
+	ID       string    `json:"id"`
+	Src      string    `json:"src"`
+	Children []*Frame  `json:"-"`
+}
+
+// FrameElement is an element that was found by FindElementInFrames along with
+// the path of the frame which contains it.
+type FrameElement struct {
+	Element Element
+	Path    FramePath
+}
+
+// childFramesScript returns the name, id and src of each frame element in the
+// current browsing context, in the same order as they are indexed by
+// SwitchToFrame.
+const childFramesScript = `
+var frames = document.querySelectorAll('iframe, frame');
+return Array.prototype.map.call(frames, function(f) {
+	return {name: f.name || '', id: f.id || '', src: f.src || ''};
+});
+`
+
+func (s *seleniumWebDriver) SwitchToFramePath(path FramePath) (*SwitchToFrameResponse, error) {
+	for _, i := range path {
+		if i < 0 {
+			return nil, errors.New("switchtoframepath: invalid path argument")
+		}
+	}
+	if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("SwitchToFramePath")
+	}
+
+	resp, err := s.switchToFrame(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range path {
+		resp, err = s.switchToFrame(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *seleniumWebDriver) FrameTree() (*Frame, error) {
+	if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("FrameTree")
+	}
+
+	_, err := s.switchToFrame(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &Frame{Path: FramePath{}}
+	err = s.walkFrameTree(root)
+
+	_, restoreErr := s.switchToFrame(nil)
+	if err != nil {
+		return nil, err
+	} else if restoreErr != nil {
+		return nil, restoreErr
+	}
+
+	return root, nil
+}
+
+// walkFrameTree adds the frames within the current browsing context (which is
+// the frame passed in) to its children and then descends into each of them.
+func (s *seleniumWebDriver) walkFrameTree(parent *Frame) error {
+	children, err := s.childFrames("FrameTree")
+	if err != nil {
+		return err
+	}
+
+	for i, child := range children {
+		child.Path = append(append(FramePath{}, parent.Path...), i)
+
+		_, err = s.switchToFrame(i)
+		if err != nil {
+			return err
+		}
+		err = s.walkFrameTree(child)
+		if err != nil {
+			return err
+		}
+		_, err = s.SwitchToParentFrame()
+		if err != nil {
+			return err
+		}
+	}
+	parent.Children = children
+
+	return nil
+}
+
+func (s *seleniumWebDriver) FindElementInFrames(by By) (*FrameElement, error) {
+	if by == nil || by.Type() == "index" {
+		return nil, errors.New("findelementinframes: invalid by argument")
+	} else if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("FindElementInFrames")
+	}
+
+	_, err := s.switchToFrame(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := s.searchFrames(by, FramePath{})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		_, err = s.switchToFrame(nil)
+		if err != nil {
+			return nil, err
+		}
+		return nil, newNoSuchElementError("FindElementInFrames", s.seleniumURL, by)
+	}
+
+	return found, nil
+}
+
+// searchFrames searches the current browsing context, which is at the path
+// passed in, and then each of its frames, depth first. The browsing context
+// is left as the frame containing the element if one is found.
+func (s *seleniumWebDriver) searchFrames(by By, path FramePath) (*FrameElement, error) {
+	elements, err := s.findElements(by, nil, "FindElementInFrames")
+	if err != nil {
+		return nil, err
+	}
+	if len(elements) > 0 {
+		return &FrameElement{Element: elements[0], Path: path}, nil
+	}
+
+	children, err := s.childFrames("FindElementInFrames")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range children {
+		_, err = s.switchToFrame(i)
+		if err != nil {
+			return nil, err
+		}
+
+		found, err := s.searchFrames(by, append(append(FramePath{}, path...), i))
+		if err != nil || found != nil {
+			return found, err
+		}
+
+		_, err = s.SwitchToParentFrame()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// childFrames describes the frames within the current browsing context.
+func (s *seleniumWebDriver) childFrames(method string) ([]*Frame, error) {
+	resp, err := s.scriptRequest(childFramesScript, nil, false, method)
+	if err != nil {
+		return nil, err
+	}
+
+	var frames []*Frame
+	err = resp.Decode(&frames)
+	if err != nil {
+		return nil, newUnmarshallingError(err, method, string(resp.Value))
+	}
+
+	return frames, nil
+}
@@ -0,0 +1,400 @@
+package goselenium
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func frameAPIService() *routedAPIService {
+	return &routedAPIService{
+		routes: map[string]string{
+			"/element":      `{"state": "success", "value": {"element": "frame-1"}}`,
+			"/frame":        `{"state": "success"}`,
+			"/frame/parent": `{"state": "success"}`,
+			"/window":       `{"state": "success", "value": "CDwindow-1"}`,
+		},
+	}
+}
+
+// frameTreeAPIService simulates a page with nested frames, keeping track of
+// the frame that is currently switched to. frames contains the names of the
+// child frames of each frame (keyed by its path, such as "0/1") and elements
+// contains the ID of the element that can be found within a frame.
+type frameTreeAPIService struct {
+	frames   map[string][]string
+	elements map[string]string
+	current  []string
+}
+
+func (f *frameTreeAPIService) performRequest(url string, method string, body io.Reader) ([]byte, error) {
+	var b []byte
+	if body != nil {
+		b, _ = ioutil.ReadAll(body)
+	}
+	path := strings.Join(f.current, "/")
+
+	switch {
+	case strings.HasSuffix(url, "/frame/parent"):
+		f.current = f.current[:len(f.current)-1]
+	case strings.HasSuffix(url, "/frame"):
+		var req struct {
+			ID *int `json:"id"`
+		}
+		json.Unmarshal(b, &req)
+		if req.ID == nil {
+			f.current = nil
+		} else if *req.ID >= len(f.frames[path]) {
+			return nil, &requestError{State: NoSuchFrame}
+		} else {
+			f.current = append(f.current, fmt.Sprint(*req.ID))
+		}
+	case strings.HasSuffix(url, "/execute"):
+		frames := []string{}
+		for _, name := range f.frames[path] {
+			frames = append(frames, fmt.Sprintf(`{"name": "%s", "id": "", "src": "/%s.html"}`, name, name))
+		}
+		return []byte(`{"state": "success", "value": [` + strings.Join(frames, ",") + `]}`), nil
+	case strings.HasSuffix(url, "/elements"):
+		if id, ok := f.elements[path]; ok {
+			return []byte(`{"state": "success", "value": [{"element": "` + id + `"}]}`), nil
+		}
+		return []byte(`{"state": "success", "value": []}`), nil
+	}
+
+	return []byte(`{"state": "success"}`), nil
+}
+
+func setUpFrameTree() (*seleniumWebDriver, *frameTreeAPIService) {
+	api := &frameTreeAPIService{
+		frames: map[string][]string{
+			"":  {"header", "content"},
+			"1": {"ad", "editor"},
+		},
+		elements: map[string]string{},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	return d, api
+}
+
+/*
+SwitchToFrame tests
+*/
+func Test_Frame_SwitchToFrameByElementSendsAReference(t *testing.T) {
+	api := frameAPIService()
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.SwitchToFrame(ByElement(newSeleniumElement("frame-2", d)))
+	if err != nil || len(api.requests) != 1 {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if !strings.Contains(api.bodies[0], `"`+webElementIdentifier+`":"frame-2"`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Frame_SwitchToFrameByNameFindsTheFrameElement(t *testing.T) {
+	api := frameAPIService()
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.SwitchToFrame(ByFrameName("content"))
+	if err != nil || len(api.requests) != 2 {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if !strings.Contains(api.bodies[0], `iframe[name=\"content\"]`) ||
+		!strings.Contains(api.bodies[1], `"`+webElementIdentifier+`":"frame-1"`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Frame_SwitchToFrameReturnsErrorIfFrameIsNotFound(t *testing.T) {
+	api := frameAPIService()
+	api.errors = map[string]error{
+		"/element": &requestError{State: NoSuchElement},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.SwitchToFrame(ByCSSSelector("iframe.missing"))
+	if err == nil || len(api.requests) != 1 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+SwitchToDefaultContent tests
+*/
+func Test_Frame_SwitchToDefaultContentInvalidSessionIdResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	_, err := d.SwitchToDefaultContent()
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_Frame_SwitchToDefaultContentSendsANullID(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success"}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.SwitchToDefaultContent()
+	if err != nil || resp.State != "success" || api.lastBody != `{"id":null}` {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+WithinFrame tests
+*/
+func Test_Frame_WithinFrameRestoresTheParentFrame(t *testing.T) {
+	api := frameAPIService()
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	called := false
+	err := d.WithinFrame(ByIndex(0), func() error {
+		called = true
+		return nil
+	})
+	if err != nil || !called {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if len(api.requests) != 2 || !strings.HasSuffix(api.requests[1], "/frame/parent") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Frame_WithinFrameRestoresTheParentFrameOnError(t *testing.T) {
+	api := frameAPIService()
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	expected := errors.New("failed")
+	err := d.WithinFrame(ByIndex(0), func() error {
+		return expected
+	})
+	if err != expected {
+		t.Errorf(correctResponseErrorText)
+	}
+	if len(api.requests) != 2 || !strings.HasSuffix(api.requests[1], "/frame/parent") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Frame_WithinFrameReentersThePreviousFrame(t *testing.T) {
+	for _, expected := range []error{nil, errors.New("failed")} {
+		d, api := setUpFrameTree()
+		d.SwitchToFramePath(FramePath{1})
+
+		err := d.WithinFrame(ByIndex(1), func() error {
+			d.SwitchToFrame(ByIndex(0))
+			return expected
+		})
+		if err != expected || strings.Join(api.current, "/") != "1" {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_Frame_WithinFrameFallsBackToTheTopLevelOnError(t *testing.T) {
+	api := frameAPIService()
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	d.SwitchToFrame(ByCSSSelector("iframe"))
+	expected := errors.New("failed")
+	err := d.WithinFrame(ByIndex(0), func() error {
+		d.SwitchToFrame(ByIndex(0))
+		return expected
+	})
+	if err != expected {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	last := len(api.requests) - 1
+	if !strings.HasSuffix(api.requests[last], "/frame") || api.bodies[last] != `{"id":null}` {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Frame_WithinFrameDoesNotCallFIfSwitchingFails(t *testing.T) {
+	api := frameAPIService()
+	api.errors = map[string]error{
+		"/frame": &requestError{State: NoSuchFrame},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	err := d.WithinFrame(ByIndex(3), func() error {
+		t.Errorf(correctResponseErrorText)
+		return nil
+	})
+	if err == nil || len(api.requests) != 1 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+WithinWindow tests
+*/
+func Test_Frame_WithinWindowRestoresThePreviousWindow(t *testing.T) {
+	api := frameAPIService()
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	expected := errors.New("failed")
+	err := d.WithinWindow("CDwindow-2", func() error {
+		return expected
+	})
+	if err != expected {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	if len(api.requests) != 3 || !strings.HasPrefix(api.requests[0], "GET") {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if !strings.Contains(api.bodies[1], `"handle":"CDwindow-2"`) ||
+		!strings.Contains(api.bodies[2], `"handle":"CDwindow-1"`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Frame_WithinWindowInvalidFunctionResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), frameAPIService())
+	d.sessionID = "12345"
+
+	err := d.WithinWindow("CDwindow-2", nil)
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+/*
+SwitchToFramePath tests
+*/
+func Test_Frame_SwitchToFramePathInvalidPathResultsInError(t *testing.T) {
+	d, _ := setUpFrameTree()
+
+	_, err := d.SwitchToFramePath(FramePath{0, -1})
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_Frame_SwitchToFramePathStartsFromTheTopLevel(t *testing.T) {
+	d, api := setUpFrameTree()
+	api.current = []string{"0"}
+
+	_, err := d.SwitchToFramePath(FramePath{1, 1})
+	if err != nil || strings.Join(api.current, "/") != "1/1" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Frame_SwitchToFramePathReturnsErrorIfFrameDoesNotExist(t *testing.T) {
+	d, _ := setUpFrameTree()
+
+	_, err := d.SwitchToFramePath(FramePath{0, 0})
+	if err == nil || !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+/*
+FrameTree tests
+*/
+func Test_Frame_FrameTreeInvalidSessionIdResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	_, err := d.FrameTree()
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_Frame_FrameTreeEnumeratesNestedFrames(t *testing.T) {
+	d, api := setUpFrameTree()
+
+	root, err := d.FrameTree()
+	if err != nil || len(root.Children) != 2 || len(root.Path) != 0 {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	header, content := root.Children[0], root.Children[1]
+	if header.Name != "header" || header.Src != "/header.html" || len(header.Children) != 0 {
+		t.Errorf(correctResponseErrorText)
+	}
+	if content.Name != "content" || len(content.Children) != 2 {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	editor := content.Children[1]
+	if editor.Name != "editor" || fmt.Sprint(editor.Path) != "[1 1]" {
+		t.Errorf(correctResponseErrorText)
+	}
+	if len(api.current) != 0 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+FindElementInFrames tests
+*/
+func Test_Frame_FindElementInFramesInvalidByResultsInError(t *testing.T) {
+	d, _ := setUpFrameTree()
+
+	for _, b := range []By{nil, ByIndex(0)} {
+		_, err := d.FindElementInFrames(b)
+		if err == nil {
+			t.Errorf(argumentErrorText)
+		}
+	}
+}
+
+func Test_Frame_FindElementInFramesSearchesTheTopLevelFirst(t *testing.T) {
+	d, api := setUpFrameTree()
+	api.elements[""] = "top"
+	api.elements["1/1"] = "nested"
+
+	found, err := d.FindElementInFrames(ByCSSSelector("textarea"))
+	if err != nil || found.Element.ID() != "top" || len(found.Path) != 0 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Frame_FindElementInFramesLeavesTheContainingFrameSwitchedTo(t *testing.T) {
+	d, api := setUpFrameTree()
+	api.elements["1/1"] = "editor-body"
+
+	found, err := d.FindElementInFrames(ByCSSSelector("textarea"))
+	if err != nil || found.Element.ID() != "editor-body" {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if fmt.Sprint(found.Path) != "[1 1]" || strings.Join(api.current, "/") != "1/1" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Frame_FindElementInFramesReturnsNoSuchElementIfNotFound(t *testing.T) {
+	d, api := setUpFrameTree()
+
+	_, err := d.FindElementInFrames(ByCSSSelector("textarea"))
+	comErr, ok := err.(CommunicationError)
+	if !ok || comErr.Response.State != NoSuchElement {
+		t.Errorf(correctResponseErrorText)
+	}
+	if len(api.current) != 0 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
@@ -1,6 +1,9 @@
 package goselenium
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Until represents a function that will be continuously repeated until it
 // succeeds or a timeout is reached.
@@ -8,22 +11,26 @@ type Until func(w WebDriver) bool
 
 // UntilElementPresent attempts to locate an element on the page. It is
 // determined as existing if the state is 'Success' and the error is nil.
-func UntilElementPresent(by By) Until {
-	return func(w WebDriver) bool {
-		_, err := w.FindElement(by)
-		return err == nil
-	}
+// Otherwise the error is returned, so that WaitContext can report it.
+func UntilElementPresent(by By) Condition {
+	return elementCondition(by, "to be present", func(w WebDriver, el Element) (bool, error) {
+		return true, nil
+	})
 }
 
 // UntilURLIs checks whether or not the page's URL has changed.
-func UntilURLIs(url string) Until {
-	return func(w WebDriver) bool {
+func UntilURLIs(url string) Condition {
+	return NewCondition(fmt.Sprintf("URL to be %q", url), func(w WebDriver) (bool, error) {
 		resp, err := w.CurrentURL()
-		return err == nil && resp.URL == url
-	}
+		if err != nil {
+			return false, err
+		}
+
+		return resp.URL == url, nil
+	})
 }
 
-func (s *seleniumWebDriver) Wait(u Until, timeout time.Duration, sleep time.Duration) bool {
+func (s *seleniumWebDriver) Wait(c Condition, timeout time.Duration, sleep time.Duration) bool {
 	response := make(chan bool, 1)
 	quit := make(chan bool, 1)
 
@@ -34,8 +41,8 @@ func (s *seleniumWebDriver) Wait(u Until, timeout time.Duration, sleep time.Dura
 			case <-quit:
 				break outer
 			default:
-				e := u(s)
-				if e {
+				ok, err := c.Check(s)
+				if ok && err == nil {
 					response <- true
 					break outer
 				}
@@ -0,0 +1,32 @@
+package goselenium
+
+import (
+	"testing"
+	"time"
+)
+
+/*
+	Wait tests
+*/
+func Test_Wait_AcceptsUntilFunctionsAndConditions(t *testing.T) {
+	d, _ := setUpConditions(map[string]string{
+		"/title": `{"state": "success", "value": "Checkout"}`,
+	}, nil)
+
+	if !d.Wait(Until(func(w WebDriver) bool { return true }), time.Second, 0) {
+		t.Errorf(correctResponseErrorText)
+	}
+	if !d.Wait(UntilTitleIs("Checkout"), time.Second, 0) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Wait_ConditionErrorsAreNotSatisfied(t *testing.T) {
+	d, _ := setUpConditions(nil, map[string]error{
+		"/title": &requestError{State: UnknownError},
+	})
+
+	if d.Wait(UntilTitleIs("Checkout"), 20*time.Millisecond, time.Millisecond) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
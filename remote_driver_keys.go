@@ -0,0 +1,107 @@
+package goselenium
+
+import (
+	"strings"
+	"time"
+)
+
+// modifierKeys are the keys which remain pressed whilst sending keys until
+// NullKey is sent.
+var modifierKeys = map[rune]bool{
+	'\uE008': true, // Shift
+	'\uE009': true, // Control
+	'\uE00A': true, // Alt
+	'\uE03D': true, // Meta
+	'\uE050': true, // Right shift
+	'\uE051': true, // Right control
+	'\uE052': true, // Right alt
+	'\uE053': true, // Right meta
+}
+
+// Chord combines keys so that they are pressed together when passed to
+// SendKeys, i.e. Chord(ControlKey, ShiftKey, "k") presses Ctrl+Shift+K. The
+// modifier keys are released at the end of the chord, so chords can be
+// followed by other keys:
+//
+//	el.SendKeys(Chord(ControlKey, "a") + BackspaceKey)
+func Chord(keys ...string) string {
+	return strings.Join(keys, "") + NullKey
+}
+
+// PrimaryModifierKey returns MetaKey if the platform of the session is macOS
+// and ControlKey otherwise. The platform is read from the capabilities
+// returned when the session was created.
+func (s *seleniumWebDriver) PrimaryModifierKey() string {
+	platform := strings.ToLower(s.platformName)
+	for _, mac := range []string{"mac", "darwin", "os x"} {
+		if strings.Contains(platform, mac) {
+			return MetaKey
+		}
+	}
+
+	return ControlKey
+}
+
+// Chord presses every key in order and then releases them in reverse order,
+// i.e. Chord(ControlKey, ShiftKey, "k") presses Ctrl+Shift+K.
+func (a *Actions) Chord(keys ...string) *Actions {
+	k := a.keyboard()
+	for _, key := range keys {
+		k.KeyDown(key)
+	}
+	for i := len(keys) - 1; i >= 0; i-- {
+		k.KeyUp(keys[i])
+	}
+
+	return a
+}
+
+func (s *seleniumElement) SendKeysWithDelay(keys string, delay time.Duration) (*ElementSendKeysResponse, error) {
+	var resp *ElementSendKeysResponse
+	for i, k := range keyStrokes(keys) {
+		if i > 0 {
+			time.Sleep(delay)
+		}
+
+		var err error
+		resp, err = s.SendKeys(k)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp == nil {
+		return s.SendKeys("")
+	}
+
+	return resp, nil
+}
+
+// keyStrokes splits keys into the strings that are sent individually by
+// SendKeysWithDelay. Each character is a keystroke of its own, except when a
+// modifier key is pressed; everything up to and including the next NullKey
+// is then kept together so that the modifier applies to it.
+func keyStrokes(keys string) []string {
+	var strokes []string
+	var chord strings.Builder
+	for _, r := range keys {
+		switch {
+		case chord.Len() > 0:
+			chord.WriteRune(r)
+			if string(r) == NullKey {
+				strokes = append(strokes, chord.String())
+				chord.Reset()
+			}
+		case modifierKeys[r]:
+			chord.WriteRune(r)
+		default:
+			strokes = append(strokes, string(r))
+		}
+	}
+
+	if chord.Len() > 0 {
+		strokes = append(strokes, chord.String())
+	}
+
+	return strokes
+}
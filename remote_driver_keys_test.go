@@ -0,0 +1,125 @@
+package goselenium
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Keys_ChordEndsWithNullKey(t *testing.T) {
+	chord := Chord(ControlKey, ShiftKey, "k")
+	if chord != ControlKey+ShiftKey+"k"+NullKey {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Keys_PrimaryModifierKeyDependsOnPlatform(t *testing.T) {
+	platforms := map[string]string{
+		"mac":      MetaKey,
+		"macOS":    MetaKey,
+		"Mac OS X": MetaKey,
+		"darwin":   MetaKey,
+		"windows":  ControlKey,
+		"linux":    ControlKey,
+		"":         ControlKey,
+	}
+	for platform, expected := range platforms {
+		d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+		d.platformName = platform
+		if d.PrimaryModifierKey() != expected {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_Keys_PlatformNameIsReadFromSessionCapabilities(t *testing.T) {
+	responses := []string{
+		`{"sessionId": "1", "value": {"platformName": "mac"}}`,
+		`{"sessionId": "1", "value": {"platform": "MAC"}}`,
+	}
+	for _, r := range responses {
+		api := &testableAPIService{jsonToReturn: r}
+		d := setUpDriver(setUpDefaultCaps(), api)
+
+		resp, err := d.CreateSession()
+		if err != nil || !strings.EqualFold(resp.Capabilities.PlatformName, "mac") ||
+			d.PrimaryModifierKey() != MetaKey {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_Keys_KeyStrokesKeepChordsTogether(t *testing.T) {
+	strokes := keyStrokes("ab" + Chord(ControlKey, "a") + "c" + ShiftKey + "d")
+	expected := []string{"a", "b", ControlKey + "a" + NullKey, "c", ShiftKey + "d"}
+	if len(strokes) != len(expected) {
+		t.Fatalf(correctResponseErrorText)
+	}
+	for i := range expected {
+		if strokes[i] != expected[i] {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_Keys_SendKeysWithDelaySendsEachKeyStroke(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/value": `{"state": "success"}`,
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	start := time.Now()
+	resp, err := newSeleniumElement("0", d).SendKeysWithDelay("hé"+Chord(ControlKey, "a"), 10*time.Millisecond)
+	if err != nil || resp.State != "success" || len(api.bodies) != 3 {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	var body struct {
+		Value []string `json:"value"`
+	}
+	json.Unmarshal([]byte(api.bodies[1]), &body)
+	if len(body.Value) != 1 || body.Value[0] != "é" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Keys_SendKeysSplitsMultiByteCharacters(t *testing.T) {
+	api := &testableAPIService{jsonToReturn: `{"state": "success"}`}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := newSeleniumElement("0", d).SendKeys("né" + EnterKey)
+	if err != nil {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	var body struct {
+		Value []string `json:"value"`
+	}
+	json.Unmarshal([]byte(api.lastBody), &body)
+	if len(body.Value) != 3 || body.Value[1] != "é" || body.Value[2] != EnterKey {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Keys_ActionsChordReleasesKeysInReverse(t *testing.T) {
+	s := sequences(t, NewActions().Chord(ControlKey, ShiftKey, "k"))
+
+	var values []string
+	for _, a := range s[0]["actions"].([]interface{}) {
+		action := a.(map[string]interface{})
+		values = append(values, action["type"].(string)+":"+action["value"].(string))
+	}
+
+	expected := "keyDown:" + ControlKey + ",keyDown:" + ShiftKey + ",keyDown:k,keyUp:k,keyUp:" + ShiftKey + ",keyUp:" + ControlKey
+	if strings.Join(values, ",") != expected {
+		t.Errorf(correctResponseErrorText)
+	}
+}
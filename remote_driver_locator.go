@@ -0,0 +1,268 @@
+package goselenium
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	testIDMu sync.RWMutex
+	// testIDAttribute is the attribute that ByTestID searches on.
+	testIDAttribute = "data-testid"
+)
+
+// SetTestIDAttribute changes the attribute used by ByTestID (by default this is
+// data-testid). This should be called before any ByTestID locators are
+// created as the attribute is read when the locator is constructed. As it
+// affects the whole package, tests which run in parallel should use
+// ByTestIDAttr instead.
+func SetTestIDAttribute(attribute string) {
+	testIDMu.Lock()
+	defer testIDMu.Unlock()
+
+	testIDAttribute = attribute
+}
+
+// ByText finds the innermost visible elements whose normalised text (leading
+// and trailing whitespace removed, inner whitespace collapsed) is exactly
+// equal to the text passed in.
+func ByText(text string) By {
+	return scriptBy{
+		t:     "text",
+		kind:  "text",
+		query: locatorQuery{Mode: "exact", Text: text},
+	}
+}
+
+// ByPartialText works the same way as ByText but matches elements whose text
+// contains the string passed in.
+func ByPartialText(text string) By {
+	return scriptBy{
+		t:     "partial text",
+		kind:  "text",
+		query: locatorQuery{Mode: "contains", Text: text},
+	}
+}
+
+// ByTextMatching works the same way as ByText but matches elements whose text
+// matches a regular expression. As the expression is evaluated by the browser,
+// it must use JavaScript RegExp syntax. A leading (?i) is translated into the
+// case-insensitive flag.
+func ByTextMatching(pattern string) By {
+	var flags string
+	if strings.HasPrefix(pattern, "(?i)") {
+		pattern = strings.TrimPrefix(pattern, "(?i)")
+		flags = "i"
+	}
+
+	return scriptBy{
+		t:     "text matching",
+		kind:  "text",
+		query: locatorQuery{Mode: "regexp", Text: pattern, Flags: flags},
+	}
+}
+
+// ByLabel finds form controls by the text of their associated <label>
+// element, their aria-label attribute or the text of the elements referenced
+// by their aria-labelledby attribute.
+func ByLabel(text string) By {
+	return scriptBy{
+		t:     "label",
+		kind:  "label",
+		query: locatorQuery{Mode: "exact", Text: text},
+	}
+}
+
+// ByRole finds elements by their ARIA role (i.e. button, link, checkbox),
+// taking implicit roles of native elements into account. If name is not empty,
+// the accessible name of the element must also match it exactly.
+func ByRole(role string, name string) By {
+	return scriptBy{
+		t:     "role",
+		kind:  "role",
+		query: locatorQuery{Mode: "exact", Text: name, Role: role},
+	}
+}
+
+// ByPlaceholder finds input elements by their placeholder attribute.
+func ByPlaceholder(text string) By {
+	return by{
+		t:     "css selector",
+		value: fmt.Sprintf(`*[placeholder="%s"]`, cssEscape(text)),
+	}
+}
+
+// ByTestID finds elements by their test ID attribute. The attribute is
+// data-testid unless it has been changed by calling SetTestIDAttribute.
+func ByTestID(id string) By {
+	testIDMu.RLock()
+	attribute := testIDAttribute
+	testIDMu.RUnlock()
+
+	return ByTestIDAttr(attribute, id)
+}
+
+// ByTestIDAttr finds elements by a test ID held in the attribute passed in,
+// regardless of the attribute set by SetTestIDAttribute.
+func ByTestIDAttr(attribute string, id string) By {
+	return by{
+		t:     "css selector",
+		value: fmt.Sprintf(`*[%s="%s"]`, cssEscape(attribute), cssEscape(id)),
+	}
+}
+
+// locatorQuery is passed to the locator script to describe what should be
+// matched.
+type locatorQuery struct {
+	Mode  string `json:"mode"`
+	Text  string `json:"text"`
+	Flags string `json:"flags,omitempty"`
+	Role  string `json:"role,omitempty"`
+}
+
+func (l locatorQuery) String() string {
+	if l.Role != "" {
+		return fmt.Sprintf("%s %q", l.Role, l.Text)
+	}
+
+	return fmt.Sprintf("%q", l.Text)
+}
+
+// scriptBy is a By implementation which has no native W3C strategy and is
+// instead resolved by the locator script, which is pinned into the page.
+type scriptBy struct {
+	t     string
+	kind  string
+	query locatorQuery
+}
+
+func (s scriptBy) Type() string {
+	return s.t
+}
+
+func (s scriptBy) Value() interface{} {
+	return s.query
+}
+
+func (s scriptBy) find(wd *seleniumWebDriver, root *seleniumElement) ([]Element, error) {
+	var r interface{}
+	if root != nil {
+		r = elementReference(root.ID())
+	}
+
+	resp, err := executeBuiltIn(wd, locatorScriptName, locatorScript, false, "FindElements", r, s.kind, s.query)
+	if err != nil {
+		return nil, err
+	}
+
+	elements, err := resp.Elements()
+	if err != nil {
+		return nil, newUnmarshallingError(err, "FindElements", string(resp.Value))
+	}
+
+	return elements, nil
+}
+
+// locatorScript resolves the user-facing locators within the browser. It
+// accepts the root element (or null for the document), the kind of locator
+// and the locatorQuery, and returns an array of the matching elements.
+const locatorScript = `
+var root = arguments[0] || document, kind = arguments[1], q = arguments[2];
+
+function norm(s) { return (s || '').replace(/\s+/g, ' ').trim(); }
+function all(sel) { return Array.prototype.slice.call(root.querySelectorAll(sel)); }
+function textOf(e) { return norm(e.innerText !== undefined ? e.innerText : e.textContent); }
+function visible(e) {
+	if (!e.getClientRects().length) { return false; }
+	var style = window.getComputedStyle(e);
+	return style.visibility !== 'hidden' && style.display !== 'none';
+}
+function matches(t) {
+	if (q.mode === 'exact') { return t === norm(q.text); }
+	if (q.mode === 'contains') { return t.indexOf(norm(q.text)) !== -1; }
+	return new RegExp(q.text, q.flags).test(t);
+}
+function labelledBy(e) {
+	return norm(e.getAttribute('aria-labelledby').split(/\s+/).map(function(id) {
+		var l = document.getElementById(id);
+		return l ? textOf(l) : '';
+	}).join(' '));
+}
+
+var implicitRoles = {
+	A: function(e) { return e.hasAttribute('href') ? 'link' : ''; },
+	ARTICLE: 'article', ASIDE: 'complementary', BUTTON: 'button', DIALOG: 'dialog',
+	FOOTER: 'contentinfo', FORM: 'form', HEADER: 'banner', HR: 'separator',
+	H1: 'heading', H2: 'heading', H3: 'heading', H4: 'heading', H5: 'heading', H6: 'heading',
+	IMG: function(e) { return e.getAttribute('alt') === '' ? 'presentation' : 'img'; },
+	INPUT: function(e) {
+		return {
+			button: 'button', checkbox: 'checkbox', email: 'textbox', image: 'button',
+			number: 'spinbutton', password: 'textbox', radio: 'radio', range: 'slider',
+			reset: 'button', search: 'searchbox', submit: 'button', tel: 'textbox',
+			text: 'textbox', url: 'textbox'
+		}[(e.getAttribute('type') || 'text').toLowerCase()] || '';
+	},
+	LI: 'listitem', MAIN: 'main', NAV: 'navigation', OL: 'list', OPTION: 'option',
+	PROGRESS: 'progressbar', SECTION: 'region', TABLE: 'table', TBODY: 'rowgroup',
+	TD: 'cell', TEXTAREA: 'textbox', TFOOT: 'rowgroup', TH: 'columnheader',
+	THEAD: 'rowgroup', TR: 'row', UL: 'list',
+	SELECT: function(e) { return e.multiple || e.size > 1 ? 'listbox' : 'combobox'; }
+};
+var nameFromContent = {
+	button: 1, cell: 1, checkbox: 1, columnheader: 1, heading: 1, link: 1,
+	listitem: 1, menuitem: 1, option: 1, radio: 1, row: 1, switch: 1, tab: 1,
+	treeitem: 1
+};
+
+function roleOf(e) {
+	var explicit = e.getAttribute('role');
+	if (explicit) { return explicit.trim().split(/\s+/)[0]; }
+	var implicit = implicitRoles[e.tagName];
+	return typeof implicit === 'function' ? implicit(e) : (implicit || '');
+}
+function nameOf(e) {
+	if (e.hasAttribute('aria-labelledby')) { return labelledBy(e); }
+	var label = norm(e.getAttribute('aria-label'));
+	if (label) { return label; }
+	if (e.labels && e.labels.length) {
+		return norm(Array.prototype.map.call(e.labels, textOf).join(' '));
+	}
+	if (e.tagName === 'IMG' && e.alt) { return norm(e.alt); }
+	if (e.tagName === 'INPUT' && /^(button|submit|reset)$/i.test(e.type)) { return norm(e.value); }
+	if (nameFromContent[roleOf(e)]) { return textOf(e); }
+	return norm(e.getAttribute('title') || e.getAttribute('placeholder'));
+}
+
+function byText() {
+	var skip = { HEAD: 1, NOSCRIPT: 1, SCRIPT: 1, STYLE: 1, TEMPLATE: 1, TITLE: 1 };
+	var found = all('*').filter(function(e) {
+		return !skip[e.tagName] && visible(e) && matches(textOf(e));
+	});
+	return found.filter(function(e) {
+		return !found.some(function(o) { return o !== e && e.contains(o); });
+	});
+}
+function byLabel() {
+	var out = [];
+	function add(e) { if (e && out.indexOf(e) === -1) { out.push(e); } }
+	all('label').forEach(function(l) { if (matches(textOf(l))) { add(l.control); } });
+	all('[aria-label]').forEach(function(e) {
+		if (matches(norm(e.getAttribute('aria-label')))) { add(e); }
+	});
+	all('[aria-labelledby]').forEach(function(e) { if (matches(labelledBy(e))) { add(e); } });
+	return out;
+}
+function byRole() {
+	return all('*').filter(function(e) {
+		return roleOf(e) === q.role && (q.text === '' || matches(nameOf(e)));
+	});
+}
+
+switch (kind) {
+	case 'label': return byLabel();
+	case 'role': return byRole();
+	default: return byText();
+}
+`
@@ -0,0 +1,155 @@
+package goselenium
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_LocatorByText_TypesAndValuesAreCorrect(t *testing.T) {
+	tests := []struct {
+		by    By
+		t     string
+		query locatorQuery
+	}{
+		{ByText("Sign in"), "text", locatorQuery{Mode: "exact", Text: "Sign in"}},
+		{ByPartialText("Sign"), "partial text", locatorQuery{Mode: "contains", Text: "Sign"}},
+		{ByTextMatching("^Sign"), "text matching", locatorQuery{Mode: "regexp", Text: "^Sign"}},
+		{ByTextMatching("(?i)sign in"), "text matching", locatorQuery{Mode: "regexp", Text: "sign in", Flags: "i"}},
+		{ByLabel("Email"), "label", locatorQuery{Mode: "exact", Text: "Email"}},
+		{ByRole("button", "Submit"), "role", locatorQuery{Mode: "exact", Text: "Submit", Role: "button"}},
+	}
+	for _, te := range tests {
+		if te.by.Type() != te.t || te.by.Value().(locatorQuery) != te.query {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_LocatorByPlaceholder_IsTranslatedToCSSSelector(t *testing.T) {
+	r := ByPlaceholder(`Search "all"`)
+	if r.Type() != "css selector" || r.Value().(string) != `*[placeholder="Search\ \"all\""]` {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_LocatorByTestID_AttributeCanBeConfigured(t *testing.T) {
+	r := ByTestID("login")
+	if r.Value().(string) != `*[data-testid="login"]` {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	SetTestIDAttribute("data-qa")
+	defer SetTestIDAttribute("data-testid")
+
+	r = ByTestID("login")
+	if r.Type() != "css selector" || r.Value().(string) != `*[data-qa="login"]` {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_LocatorByTestIDAttr_AttributeIsPassedIn(t *testing.T) {
+	r := ByTestIDAttr("data-cy", "login")
+	if r.Type() != "css selector" || r.Value().(string) != `*[data-cy="login"]` {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_LocatorFindElement_CommunicationErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: errors.New("An error :<"),
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.FindElement(ByText("Sign in"))
+	if err == nil || !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_LocatorFindElement_UnmarshallingErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": "not an array"
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.FindElement(ByLabel("Email"))
+	if err == nil || !IsUnmarshallingError(err) {
+		t.Errorf(unmarshallingErrorText)
+	}
+}
+
+func Test_LocatorFindElement_NoSuchElementIsReturnedWhenNothingMatches(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": []
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.FindElement(ByRole("button", "Submit"))
+	comErr, ok := err.(CommunicationError)
+	if !ok || comErr.Response.State != NoSuchElement {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_LocatorFindElement_ScriptIsExecutedAndFirstElementIsReturned(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": [
+				{"element-6066-11e4-a52e-4f735466cecf": "1"},
+				{"element-6066-11e4-a52e-4f735466cecf": "2"}
+			]
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	el, err := d.FindElement(ByText("Sign in"))
+	if err != nil || el.ID() != "1" {
+		t.Errorf(correctResponseErrorText)
+	}
+	if !strings.HasSuffix(api.lastURL, "/execute") ||
+		!strings.Contains(api.lastBody, `"goselenium.locator"`) ||
+		!strings.Contains(api.lastBody, `,null,"text",{"mode":"exact","text":"Sign in"}]`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_LocatorFindElements_AllElementsAreReturned(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": [
+				{"element-6066-11e4-a52e-4f735466cecf": "1"},
+				{"element-6066-11e4-a52e-4f735466cecf": "2"}
+			]
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	els, err := d.FindElements(ByPartialText("Sign"))
+	if err != nil || len(els) != 2 || els[0].ID() != "1" || els[1].ID() != "2" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
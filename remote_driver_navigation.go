@@ -76,6 +76,8 @@ func (s *seleniumWebDriver) Go(goURL string) (*GoResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Navigating switches to the top level browsing context.
+	s.setFramePath(FramePath{})
 
 	return &GoResponse{State: resp.State}, nil
 }
@@ -125,6 +127,7 @@ func (s *seleniumWebDriver) Back() (*BackResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	s.setFramePath(FramePath{})
 
 	return &BackResponse{State: resp.State}, nil
 }
@@ -147,6 +150,7 @@ func (s *seleniumWebDriver) Forward() (*ForwardResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	s.setFramePath(FramePath{})
 
 	return &ForwardResponse{State: resp.State}, nil
 }
@@ -169,6 +173,7 @@ func (s *seleniumWebDriver) Refresh() (*RefreshResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	s.setFramePath(FramePath{})
 
 	return &RefreshResponse{State: resp.State}, nil
 }
@@ -0,0 +1,339 @@
+package goselenium
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultInPageTimeout is how long each check of a PageCondition waits
+// within the browser for the condition to be met. It must be shorter than the
+// script timeout of the session (which is 30 seconds by default).
+const DefaultInPageTimeout = 5 * time.Second
+
+// InPageCheckTimeout is the longest that Check waits within the browser when a
+// PageCondition is used as a Condition (such as by WaitContext, FluentWait or
+// the combinators). Those waits cannot pass their deadline to Check, so it is
+// kept short to stop a check from running on well past the deadline.
+const InPageCheckTimeout = time.Second
+
+// inPageStrategies are the locator strategies that can be evaluated by
+// observerScript.
+var inPageStrategies = map[string]bool{
+	"css selector":      true,
+	"xpath":             true,
+	"link text":         true,
+	"partial link text": true,
+}
+
+// observerScript checks a condition within the page and then re-checks it
+// whenever the DOM changes (and on every animation frame for visibility, as
+// changes to layout and computed styles are not mutations) until it is met or
+// the timeout passes. It accepts a pageConditionSpec and passes an
+// observerResult to the async callback.
+const observerScript = `
+var spec = arguments[0], done = arguments[arguments.length - 1];
+
+function find() {
+	switch (spec.using) {
+	case 'css selector':
+		return Array.prototype.slice.call(document.querySelectorAll(spec.value));
+	case 'xpath':
+		var r = document.evaluate(spec.value, document, null, XPathResult.ORDERED_NODE_SNAPSHOT_TYPE, null);
+		var found = [];
+		for (var i = 0; i < r.snapshotLength; i++) {
+			found.push(r.snapshotItem(i));
+		}
+		return found;
+	default:
+		return Array.prototype.filter.call(document.querySelectorAll('a'), function(a) {
+			var t = (a.innerText || a.textContent || '').trim();
+			return spec.using === 'link text' ? t === spec.value : t.indexOf(spec.value) !== -1;
+		});
+	}
+}
+function visible(e) {
+	if (!e.getClientRects().length) { return false; }
+	var style = window.getComputedStyle(e);
+	return style.visibility !== 'hidden' && style.display !== 'none';
+}
+function first(els, f) {
+	for (var i = 0; i < els.length; i++) {
+		if (f(els[i])) { return {ok: true, element: els[i]}; }
+	}
+	return null;
+}
+function evaluate() {
+	var els = find();
+	switch (spec.check) {
+	case 'present':
+		return first(els, function() { return true; });
+	case 'visible':
+		return first(els, visible);
+	case 'invisible':
+		return first(els, visible) ? null : {ok: true, element: null};
+	case 'text':
+		return first(els, function(e) {
+			return (e.innerText || e.textContent || '').indexOf(spec.expected) !== -1;
+		});
+	case 'attribute':
+		return first(els, function(e) { return e.getAttribute(spec.name) === spec.expected; });
+	case 'count':
+		return els.length === spec.count ? {ok: true, element: null} : null;
+	}
+	throw new Error('unknown check ' + spec.check);
+}
+
+var finished = false, observer = null, frame = null, timer = null;
+function finish(result) {
+	if (finished) { return; }
+	finished = true;
+	if (observer) { observer.disconnect(); }
+	if (frame !== null) { cancelAnimationFrame(frame); }
+	clearTimeout(timer);
+	done(result);
+}
+function check() {
+	if (finished) { return; }
+	try {
+		var result = evaluate();
+		if (result) { finish(result); }
+	} catch (e) {
+		finish({ok: false, error: String(e)});
+	}
+}
+
+check();
+if (!finished) {
+	observer = new MutationObserver(check);
+	observer.observe(document.documentElement || document, {
+		childList: true, subtree: true, attributes: true, characterData: true
+	});
+	if (spec.check === 'visible' || spec.check === 'invisible') {
+		var tick = function() {
+			check();
+			if (!finished) { frame = requestAnimationFrame(tick); }
+		};
+		frame = requestAnimationFrame(tick);
+	}
+	timer = setTimeout(function() { finish({ok: false}); }, spec.timeout);
+}
+`
+
+// pageConditionSpec is the argument that is passed to observerScript.
+type pageConditionSpec struct {
+	Using    string `json:"using"`
+	Value    string `json:"value"`
+	Check    string `json:"check"`
+	Expected string `json:"expected,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Count    int    `json:"count"`
+	Timeout  int64  `json:"timeout"`
+}
+
+// observerResult is the value that observerScript passes to the async
+// callback.
+type observerResult struct {
+	OK      bool            `json:"ok"`
+	Element json.RawMessage `json:"element"`
+	Error   string          `json:"error"`
+}
+
+// PageCondition is a condition which is evaluated inside the browser rather
+// than by repeatedly sending commands to the remote end. Each check runs a
+// script through ExecuteScriptAsync which watches the DOM with a
+// MutationObserver (and requestAnimationFrame for visibility) and returns as
+// soon as the condition is met, or once the in-page timeout passes.
+//
+// A PageCondition is created by one of the InPage functions. It implements
+// Condition, so it can be passed to WaitContext, FluentWait and the
+// combinators, although each check then waits within the browser for no
+// longer than InPageCheckTimeout. WaitInPage waits for the whole in-page
+// timeout on each check (capped to the deadline of its context) and also
+// returns the element that satisfied it. Only the CSS selector, XPath, link
+// text and partial link text strategies (which includes ByID, ByName,
+// ByClassName and ByTagName) can be evaluated within the page.
+type PageCondition struct {
+	spec        pageConditionSpec
+	timeout     time.Duration
+	description string
+	err         error
+}
+
+func newPageCondition(method string, by By, check string, expectation string) *PageCondition {
+	c := &PageCondition{
+		spec:    pageConditionSpec{Check: check},
+		timeout: DefaultInPageTimeout,
+	}
+	if by == nil {
+		c.description = "an invalid condition"
+		c.err = invalidConditionError{message: method + ": invalid by argument"}
+		return c
+	}
+
+	value, ok := by.Value().(string)
+	if !ok || !inPageStrategies[by.Type()] {
+		c.description = "an invalid condition"
+		c.err = invalidConditionError{message: fmt.Sprintf("%s: %s cannot be evaluated within the page", method, by.Type())}
+		return c
+	}
+
+	c.spec.Using, c.spec.Value = by.Type(), value
+	c.description = fmt.Sprintf("element %s %s", describeBy(by), expectation)
+
+	return c
+}
+
+// InPageElementPresent is met once an element matching by is in the DOM.
+func InPageElementPresent(by By) *PageCondition {
+	return newPageCondition("inpageelementpresent", by, "present", "to be present")
+}
+
+// InPageElementVisible is met once an element matching by is displayed.
+func InPageElementVisible(by By) *PageCondition {
+	return newPageCondition("inpageelementvisible", by, "visible", "to be visible")
+}
+
+// InPageElementInvisible is met once no element matching by is displayed,
+// including when there are no matching elements.
+func InPageElementInvisible(by By) *PageCondition {
+	return newPageCondition("inpageelementinvisible", by, "invisible", "to be invisible")
+}
+
+// InPageTextContains is met once an element matching by has text containing
+// the string passed in.
+func InPageTextContains(by By, substr string) *PageCondition {
+	c := newPageCondition("inpagetextcontains", by, "text", fmt.Sprintf("to have text containing %q", substr))
+	c.spec.Expected = substr
+
+	return c
+}
+
+// InPageAttributeIs is met once an element matching by has an attribute with
+// the value passed in.
+func InPageAttributeIs(by By, name string, value string) *PageCondition {
+	c := newPageCondition("inpageattributeis", by, "attribute", fmt.Sprintf("to have attribute %s=%q", name, value))
+	c.spec.Name, c.spec.Expected = name, value
+
+	return c
+}
+
+// InPageElementCount is met once exactly count elements match by.
+func InPageElementCount(by By, count int) *PageCondition {
+	c := newPageCondition("inpageelementcount", by, "count", "")
+	c.spec.Count = count
+	if c.err == nil {
+		c.description = fmt.Sprintf("%d elements matching %s", count, describeBy(by))
+	}
+	if count < 0 {
+		c.err = invalidConditionError{message: "inpageelementcount: invalid count argument"}
+	}
+
+	return c
+}
+
+// WithTimeout sets how long each check by WaitInPage waits within the
+// browser. It must be greater than zero and should be shorter than the script
+// timeout of the session. If it is longer, the remote end ends each check
+// when the script timeout passes and the check just reports that the
+// condition has not been met, so it never waits in the page for the rest of
+// the timeout.
+func (c *PageCondition) WithTimeout(timeout time.Duration) *PageCondition {
+	c.timeout = timeout
+	if timeout <= 0 {
+		c.err = invalidConditionError{message: "withtimeout: invalid timeout argument"}
+	}
+
+	return c
+}
+
+func (c *PageCondition) Description() string {
+	return c.description
+}
+
+// Check waits within the browser for up to InPageCheckTimeout (or the
+// in-page timeout, if that is shorter) for the condition to be met.
+func (c *PageCondition) Check(w WebDriver) (bool, error) {
+	timeout := c.timeout
+	if timeout > InPageCheckTimeout {
+		timeout = InPageCheckTimeout
+	}
+
+	_, ok, err := c.observe(w, timeout)
+	return ok, err
+}
+
+// observe runs observerScript for up to timeout and returns the element that
+// satisfied the condition, if there is one.
+func (c *PageCondition) observe(w WebDriver, timeout time.Duration) (Element, bool, error) {
+	if c.err != nil {
+		return nil, false, c.err
+	}
+
+	spec := c.spec
+	spec.Timeout = timeout.Milliseconds()
+
+	resp, err := executeBuiltIn(w, observerScriptName, observerScript, true, "ExecuteScriptAsync", spec)
+	if hasErrorState(err, ScriptTimeout) {
+		// The script timeout of the session is shorter than the in-page
+		// timeout, which is the same as the condition not being met yet.
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	var result observerResult
+	err = resp.Decode(&result)
+	if err != nil {
+		return nil, false, newUnmarshallingError(err, "PageCondition", string(resp.Value))
+	}
+	if result.Error != "" {
+		// The locator could not be evaluated (i.e. an invalid selector), so
+		// it will never be met.
+		return nil, false, invalidConditionError{message: "pagecondition: " + result.Error}
+	}
+	if !result.OK {
+		return nil, false, nil
+	}
+	if len(result.Element) == 0 || string(result.Element) == "null" {
+		return nil, true, nil
+	}
+
+	el, err := resp.withValue(result.Element).Element()
+	if err != nil {
+		return nil, false, newUnmarshallingError(err, "PageCondition", string(result.Element))
+	}
+
+	return el, true, nil
+}
+
+// WaitInPage waits until a PageCondition is met or until the context is done,
+// in which case a WaitTimeoutError is returned. Each check waits within the
+// browser for up to the in-page timeout of the condition (or until the
+// deadline of the context, if that is sooner), so a single round trip is
+// usually all that is needed.
+//
+// The element which satisfied the condition is returned; it is nil for
+// conditions which are not met by an element, such as InPageElementInvisible.
+//
+//	el, err := goselenium.WaitInPage(ctx, d, goselenium.InPageTextContains(goselenium.ByID("status"), "Saved"))
+func WaitInPage(ctx context.Context, w WebDriver, c *PageCondition) (Element, error) {
+	if w == nil {
+		return nil, errors.New("waitinpage: invalid driver argument")
+	} else if c == nil {
+		return nil, errors.New("waitinpage: invalid condition argument")
+	}
+
+	// There is no need to sleep between checks as each of them waits within
+	// the browser.
+	return poll(ctx, "WaitInPage", c.Description(), time.Millisecond, false, retryValid, func() (Element, bool, error) {
+		timeout := c.timeout
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+			timeout = time.Until(deadline)
+		}
+
+		return c.observe(w, timeout)
+	})
+}
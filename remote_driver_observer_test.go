@@ -0,0 +1,158 @@
+package goselenium
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_PageCondition_SendsTheSpecToTheBrowser(t *testing.T) {
+	d, api := setUpConditions(map[string]string{
+		"/execute_async": `{"state": "success", "value": {"ok": false}}`,
+	}, nil)
+
+	c := InPageAttributeIs(ByID("status"), "data-state", "saved").WithTimeout(500 * time.Millisecond)
+	checkCondition(t, d, c, false)
+
+	if len(api.requests) != 1 || !strings.HasSuffix(api.requests[0], "/execute_async") {
+		t.Fatalf(correctResponseErrorText)
+	}
+	body := api.bodies[0]
+	for _, expected := range []string{
+		`"using":"css selector"`,
+		`"value":"#status"`,
+		`"check":"attribute"`,
+		`"name":"data-state"`,
+		`"expected":"saved"`,
+		`"timeout":500`,
+		`"goselenium.observer"`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected the request body to contain %s", expected)
+		}
+	}
+}
+
+func Test_PageCondition_CheckIsCappedToTheCheckTimeout(t *testing.T) {
+	d, api := setUpConditions(map[string]string{
+		"/execute_async": `{"state": "success", "value": {"ok": false}}`,
+	}, nil)
+
+	checkCondition(t, d, InPageElementVisible(ByCSSSelector(".modal")), false)
+	if !strings.Contains(api.bodies[0], `"timeout":1000`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_PageCondition_UnsupportedStrategiesAreInvalid(t *testing.T) {
+	d, api := setUpConditions(nil, nil)
+
+	invalid := []Condition{
+		InPageElementVisible(nil),
+		InPageElementPresent(ByIndex(0)),
+		InPageElementCount(ByCSSSelector("li"), -1),
+		InPageElementPresent(ByCSSSelector("li")).WithTimeout(0),
+		InPageElementPresent(ByCSSSelector("li")).WithTimeout(-time.Second),
+	}
+	for _, c := range invalid {
+		err := d.WaitContext(context.Background(), c, 0)
+		if err == nil || IsWaitTimeoutError(err) {
+			t.Errorf(argumentErrorText)
+		}
+	}
+	if len(api.requests) != 0 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_PageCondition_ScriptTimeoutIsNotSatisfied(t *testing.T) {
+	d, _ := setUpConditions(nil, map[string]error{
+		"/execute_async": &requestError{State: ScriptTimeout},
+	})
+
+	ok, err := InPageElementVisible(ByCSSSelector(".modal")).Check(d)
+	if ok || err != nil {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_PageCondition_ScriptErrorsAreInvalid(t *testing.T) {
+	d, _ := setUpConditions(map[string]string{
+		"/execute_async": `{"state": "success", "value": {"ok": false, "error": "SyntaxError: '##' is not a valid selector"}}`,
+	}, nil)
+
+	err := d.WaitContext(context.Background(), InPageElementPresent(ByCSSSelector("##")), 0)
+	if err == nil || IsWaitTimeoutError(err) || !strings.Contains(err.Error(), "not a valid selector") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_PageCondition_DescriptionsAreReadable(t *testing.T) {
+	descriptions := []struct {
+		c        Condition
+		expected string
+	}{
+		{InPageElementVisible(ByCSSSelector(".modal")), "element css selector=.modal to be visible"},
+		{InPageTextContains(ByXPath("//h1"), "Saved"), `element xpath=//h1 to have text containing "Saved"`},
+		{InPageElementCount(ByCSSSelector("li"), 3), "3 elements matching css selector=li"},
+	}
+	for _, d := range descriptions {
+		if d.c.Description() != d.expected {
+			t.Errorf("expected %q but got %q", d.expected, d.c.Description())
+		}
+	}
+}
+
+/*
+WaitInPage tests
+*/
+func Test_WaitInPage_InvalidArgumentsResultInError(t *testing.T) {
+	d, _ := setUpConditions(nil, nil)
+
+	if _, err := WaitInPage(context.Background(), nil, InPageElementPresent(ByCSSSelector("p"))); err == nil {
+		t.Errorf(argumentErrorText)
+	}
+	if _, err := WaitInPage(context.Background(), d, nil); err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_WaitInPage_ReturnsTheElement(t *testing.T) {
+	d, api := setUpConditions(map[string]string{
+		"/execute_async": `{"state": "success", "value": {"ok": true, "element": {"element-6066-11e4-a52e-4f735466cecf": "e1"}}}`,
+	}, nil)
+
+	el, err := WaitInPage(context.Background(), d, InPageTextContains(ByID("status"), "Saved"))
+	if err != nil || el == nil || el.ID() != "e1" || len(api.requests) != 1 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitInPage_ConditionsWithoutElementsReturnNil(t *testing.T) {
+	d, _ := setUpConditions(map[string]string{
+		"/execute_async": `{"state": "success", "value": {"ok": true, "element": null}}`,
+	}, nil)
+
+	el, err := WaitInPage(context.Background(), d, InPageElementInvisible(ByCSSSelector(".spinner")))
+	if err != nil || el != nil {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitInPage_TimeoutIsCappedToTheDeadline(t *testing.T) {
+	d, api := setUpConditions(map[string]string{
+		"/execute_async": `{"state": "success", "value": {"ok": false}}`,
+	}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := WaitInPage(ctx, d, InPageElementPresent(ByCSSSelector(".late")))
+	if !IsWaitTimeoutError(err) || !strings.Contains(err.Error(), "element css selector=.late to be present") {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if strings.Contains(api.bodies[0], `"timeout":5000`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
@@ -0,0 +1,252 @@
+package goselenium
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// invokePinnedScript calls a pinned script if the version that is installed
+// in the page matches, otherwise it reports that it needs to be installed.
+const invokePinnedScript = `
+var pinned = window.__goselenium_pinned;
+var script = pinned && pinned[arguments[0]];
+if (!script || script.version !== arguments[1]) {
+	return {installed: false};
+}
+return {installed: true, value: script.fn.apply(null, Array.prototype.slice.call(arguments, 2))};
+`
+
+// installPinnedScript installs a pinned script into the page and then calls
+// it, so that installing does not need an extra request.
+const installPinnedScript = `
+var pinned = window.__goselenium_pinned = window.__goselenium_pinned || {};
+pinned[arguments[0]] = {version: arguments[1], fn: function() {
+/* script */
+}};
+return {installed: true, value: pinned[arguments[0]].fn.apply(null, Array.prototype.slice.call(arguments, 2))};
+`
+
+// invokePinnedAsyncScript works the same way as invokePinnedScript for
+// scripts that are executed asynchronously. The pinned script is passed a
+// callback of its own which wraps the value that it is called with.
+const invokePinnedAsyncScript = `
+var done = arguments[arguments.length - 1];
+var pinned = window.__goselenium_pinned;
+var script = pinned && pinned[arguments[0]];
+if (!script || script.version !== arguments[1]) {
+	done({installed: false});
+	return;
+}
+var args = Array.prototype.slice.call(arguments, 2, arguments.length - 1);
+args.push(function(value) { done({installed: true, value: value}); });
+script.fn.apply(null, args);
+`
+
+// installPinnedAsyncScript works the same way as installPinnedScript for
+// scripts that are executed asynchronously.
+const installPinnedAsyncScript = `
+var done = arguments[arguments.length - 1];
+var pinned = window.__goselenium_pinned = window.__goselenium_pinned || {};
+pinned[arguments[0]] = {version: arguments[1], fn: function() {
+/* script */
+}};
+var args = Array.prototype.slice.call(arguments, 2, arguments.length - 1);
+args.push(function(value) { done({installed: true, value: value}); });
+pinned[arguments[0]].fn.apply(null, args);
+`
+
+// Names of the scripts used internally, which are pinned in the registry of
+// each driver (see builtInScripts).
+const (
+	locatorScriptName  = "goselenium.locator"
+	visibleScriptName  = "goselenium.visible"
+	observerScriptName = "goselenium.observer"
+)
+
+// ScriptRegistry holds named scripts which are installed into the page the
+// first time that they are executed, after which only their name and
+// arguments are sent. If the page navigates (removing the installed
+// scripts), they are installed again the next time that they are executed.
+// It is created by calling NewScriptRegistry and is safe for concurrent use.
+//
+// Each driver also pins the scripts that it uses internally (those behind
+// ByText, ByLabel, ByRole, UntilElementVisible and the InPage conditions) in
+// a registry of its own, so that only the first use of each of them on a page
+// sends the whole script. That first use costs an extra request, as the script
+// is only sent once the page reports that it is not installed, so scripts
+// which are large and are called repeatedly on the same page benefit the most.
+//
+//	r := goselenium.NewScriptRegistry(d)
+//	r.Pin("visible", visibilityScript)
+//	resp, err := r.Execute("visible", el)
+type ScriptRegistry struct {
+	d       WebDriver
+	mu      sync.RWMutex
+	scripts map[string]pinnedScript
+}
+
+type pinnedScript struct {
+	source  string
+	version string
+}
+
+type pinnedResult struct {
+	Installed bool            `json:"installed"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// NewScriptRegistry creates a script registry for the session of the driver
+// passed in.
+func NewScriptRegistry(d WebDriver) *ScriptRegistry {
+	return &ScriptRegistry{d: d, scripts: map[string]pinnedScript{}}
+}
+
+// Pin adds a script to the registry under the name passed in, replacing any
+// script that is already pinned with that name. As with ExecuteScript, the
+// script is the body of a function and its arguments are available through
+// the 'arguments' array.
+func (r *ScriptRegistry) Pin(name string, script string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("pin: invalid name argument")
+	} else if strings.TrimSpace(script) == "" {
+		return errors.New("pin: invalid script argument")
+	}
+
+	hash := sha1.Sum([]byte(script))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.scripts[name] = pinnedScript{source: script, version: hex.EncodeToString(hash[:])}
+	return nil
+}
+
+// Unpin removes a script from the registry. Any copy that is installed in the
+// current page is left in place but is no longer used.
+func (r *ScriptRegistry) Unpin(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.scripts, name)
+}
+
+// Pinned returns whether a script is pinned with the name passed in.
+func (r *ScriptRegistry) Pinned(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.scripts[name]
+	return ok
+}
+
+// Execute executes a pinned script with the arguments passed in, installing
+// it into the page first if it is not already installed. The response is the
+// same as if the script had been executed by ExecuteScript.
+func (r *ScriptRegistry) Execute(name string, args ...interface{}) (*ExecuteScriptResponse, error) {
+	return r.execute(name, args, false, "Execute")
+}
+
+// ExecuteAsync works the same way as Execute but executes the script
+// asynchronously, as ExecuteScriptAsync does. The callback which the script
+// must call is the last of its arguments.
+func (r *ScriptRegistry) ExecuteAsync(name string, args ...interface{}) (*ExecuteScriptResponse, error) {
+	return r.execute(name, args, true, "ExecuteAsync")
+}
+
+func (r *ScriptRegistry) execute(name string, args []interface{}, async bool, method string) (*ExecuteScriptResponse, error) {
+	if r.d == nil {
+		return nil, fmt.Errorf("%s: invalid driver argument", strings.ToLower(method))
+	}
+
+	r.mu.RLock()
+	script, ok := r.scripts[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%s: no script is pinned with the name %q", strings.ToLower(method), name)
+	}
+
+	invoke, install := invokePinnedScript, installPinnedScript
+	if async {
+		invoke, install = invokePinnedAsyncScript, installPinnedAsyncScript
+	}
+	scriptArgs := append([]interface{}{name, script.version}, args...)
+
+	resp, err := r.run(invoke, scriptArgs, async, method)
+	if err != nil {
+		return nil, err
+	}
+
+	var result pinnedResult
+	err = resp.Decode(&result)
+	if err != nil {
+		return nil, newUnmarshallingError(err, method, string(resp.Value))
+	}
+
+	if !result.Installed {
+		// The page has not had the script installed yet (or has navigated
+		// since), so the whole script is sent instead.
+		install = strings.Replace(install, scriptPlaceholder, script.source, 1)
+		resp, err = r.run(install, scriptArgs, async, method)
+		if err != nil {
+			return nil, err
+		}
+
+		err = resp.Decode(&result)
+		if err != nil {
+			return nil, newUnmarshallingError(err, method, string(resp.Value))
+		}
+	}
+
+	return resp.withValue(result.Value), nil
+}
+
+// run executes a script through the driver. The internal registry of a driver
+// sends the request directly so that errors are reported against the method
+// which used the script (such as FindElements) rather than ExecuteScript.
+func (r *ScriptRegistry) run(script string, args []interface{}, async bool, method string) (*ExecuteScriptResponse, error) {
+	if s, ok := r.d.(*seleniumWebDriver); ok && r == s.pinned {
+		return s.scriptRequest(script, args, async, method)
+	}
+	if async {
+		return r.d.ExecuteScriptAsync(script, args...)
+	}
+
+	return r.d.ExecuteScript(script, args...)
+}
+
+// builtInScripts returns the registry of the driver which holds the scripts
+// that it uses internally, creating it the first time that it is needed.
+func (s *seleniumWebDriver) builtInScripts() *ScriptRegistry {
+	s.pinnedOnce.Do(func() {
+		r := NewScriptRegistry(s)
+		r.Pin(locatorScriptName, locatorScript)
+		r.Pin(visibleScriptName, visibleScript)
+		r.Pin(observerScriptName, observerScript)
+		s.pinned = r
+	})
+
+	return s.pinned
+}
+
+// executeBuiltIn executes one of the scripts used internally through the
+// registry of the driver. Other implementations of WebDriver are sent the
+// whole script each time.
+func executeBuiltIn(w WebDriver, name string, source string, async bool, method string, args ...interface{}) (*ExecuteScriptResponse, error) {
+	s, ok := w.(*seleniumWebDriver)
+	if !ok {
+		if async {
+			return w.ExecuteScriptAsync(source, args...)
+		}
+		return w.ExecuteScript(source, args...)
+	}
+	if len(s.sessionID) == 0 {
+		return nil, newSessionIDError(method)
+	}
+
+	return s.builtInScripts().execute(name, args, async, method)
+}
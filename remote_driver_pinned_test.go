@@ -0,0 +1,205 @@
+package goselenium
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// pageAPIService imitates a page which pinned scripts can be installed into.
+// Clearing installed imitates navigating to another page.
+type pageAPIService struct {
+	installed map[string]string
+	urls      []string
+	scripts   []string
+	result    string
+}
+
+func (p *pageAPIService) performRequest(url string, method string, body io.Reader) ([]byte, error) {
+	b, _ := ioutil.ReadAll(body)
+	p.urls = append(p.urls, url)
+
+	var req struct {
+		Script string        `json:"script"`
+		Args   []interface{} `json:"args"`
+	}
+	json.Unmarshal(b, &req)
+	p.scripts = append(p.scripts, req.Script)
+
+	name, version := req.Args[0].(string), req.Args[1].(string)
+	if strings.Contains(req.Script, "window.__goselenium_pinned = ") {
+		p.installed[name] = version
+	} else if p.installed[name] != version {
+		return []byte(`{"state": "success", "value": {"installed": false}}`), nil
+	}
+
+	return []byte(`{"state": "success", "value": {"installed": true, "value": ` + p.result + `}}`), nil
+}
+
+func setUpPage(result string) (*seleniumWebDriver, *pageAPIService) {
+	api := &pageAPIService{installed: map[string]string{}, result: result}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	return d, api
+}
+
+func setUpScriptRegistry() (*ScriptRegistry, *pageAPIService) {
+	d, api := setUpPage("42")
+	return NewScriptRegistry(d), api
+}
+
+func Test_ScriptRegistry_InvalidScriptsCannotBePinned(t *testing.T) {
+	r, _ := setUpScriptRegistry()
+
+	if r.Pin("", "return 1;") == nil || r.Pin("name", " ") == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_ScriptRegistry_UnpinnedScriptsCannotBeExecuted(t *testing.T) {
+	r, _ := setUpScriptRegistry()
+
+	r.Pin("answer", "return 42;")
+	r.Unpin("answer")
+
+	_, err := r.Execute("answer")
+	if err == nil || r.Pinned("answer") {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_ScriptRegistry_ScriptIsInstalledOnce(t *testing.T) {
+	r, api := setUpScriptRegistry()
+	r.Pin("answer", "return 42;")
+
+	for i := 0; i < 3; i++ {
+		resp, err := r.Execute("answer", i)
+		if err != nil || resp.Response != "42" {
+			t.Fatalf(correctResponseErrorText)
+		}
+	}
+
+	// The first execution is attempted, then installs the script; every
+	// other execution only sends the name and arguments.
+	if len(api.scripts) != 4 {
+		t.Fatalf(correctResponseErrorText)
+	}
+	for i, s := range api.scripts {
+		installs := strings.Contains(s, "return 42;")
+		if installs != (i == 1) {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_ScriptRegistry_ScriptIsReinstalledAfterNavigation(t *testing.T) {
+	r, api := setUpScriptRegistry()
+	r.Pin("answer", "return 42;")
+
+	r.Execute("answer")
+	api.installed = map[string]string{}
+	r.Execute("answer")
+
+	if len(api.scripts) != 4 || !strings.Contains(api.scripts[3], "return 42;") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ScriptRegistry_ChangedScriptIsReinstalled(t *testing.T) {
+	r, api := setUpScriptRegistry()
+	r.Pin("answer", "return 42;")
+	r.Execute("answer")
+
+	r.Pin("answer", "return 43;")
+	r.Execute("answer")
+
+	if len(api.scripts) != 4 || !strings.Contains(api.scripts[3], "return 43;") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ScriptRegistry_ResultsAndArgumentsMatchExecuteScript(t *testing.T) {
+	r, api := setUpScriptRegistry()
+	api.result = `[{"element-6066-11e4-a52e-4f735466cecf": "b"}]`
+	r.Pin("children", "return arguments[0].children;")
+
+	el := newSeleniumElement("a", nil)
+	resp, err := r.Execute("children", el)
+	if err != nil {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	els, err := resp.Elements()
+	if err != nil || len(els) != 1 || els[0].ID() != "b" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ScriptRegistry_AsyncScriptsAreInstalledOnce(t *testing.T) {
+	r, api := setUpScriptRegistry()
+	r.Pin("answer", "arguments[arguments.length - 1](42);")
+
+	for i := 0; i < 2; i++ {
+		resp, err := r.ExecuteAsync("answer")
+		if err != nil || resp.Response != "42" {
+			t.Fatalf(correctResponseErrorText)
+		}
+	}
+
+	if len(api.scripts) != 3 || !strings.Contains(api.scripts[1], "arguments[arguments.length - 1](42);") {
+		t.Fatalf(correctResponseErrorText)
+	}
+	for _, url := range api.urls {
+		if !strings.HasSuffix(url, "/execute_async") {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_ScriptRegistry_BuiltInScriptsArePinnedForEachDriver(t *testing.T) {
+	d, api := setUpPage(`[{"element-6066-11e4-a52e-4f735466cecf": "1"}]`)
+
+	for i := 0; i < 2; i++ {
+		els, err := d.FindElements(ByText("Sign in"))
+		if err != nil || len(els) != 1 || els[0].ID() != "1" {
+			t.Fatalf(correctResponseErrorText)
+		}
+	}
+	api.result = "true"
+	visible, err := isVisible(d, newSeleniumElement("1", d))
+	if err != nil || !visible {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	// The locator script is sent in full once, after which only its name
+	// and arguments are sent; the visibility script is then installed too.
+	if len(api.scripts) != 5 {
+		t.Fatalf(correctResponseErrorText)
+	}
+	for i, s := range api.scripts {
+		if strings.Contains(s, locatorScript) != (i == 1) || strings.Contains(s, visibleScript) != (i == 4) {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+
+	other, _ := setUpPage("true")
+	if d.builtInScripts() == other.builtInScripts() {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ScriptRegistry_PageConditionsArePinned(t *testing.T) {
+	d, api := setUpPage(`{"ok": true, "element": null}`)
+
+	c := InPageElementInvisible(ByCSSSelector(".spinner"))
+	checkCondition(t, d, c, true)
+	checkCondition(t, d, c, true)
+
+	if len(api.scripts) != 3 || !strings.Contains(api.scripts[1], "MutationObserver") ||
+		strings.Contains(api.scripts[2], "MutationObserver") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
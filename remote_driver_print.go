@@ -0,0 +1,135 @@
+package goselenium
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Page orientations that can be used when printing.
+const (
+	PortraitOrientation  = "portrait"
+	LandscapeOrientation = "landscape"
+)
+
+// PrintOptions are the options that can be passed to the Print method. Any
+// option that is not set uses the default of the remote end, which is a
+// portrait US letter page with 1cm margins, scaled to 1 and shrunk to fit.
+type PrintOptions struct {
+	// Orientation is either PortraitOrientation or LandscapeOrientation.
+	Orientation string `json:"orientation,omitempty"`
+
+	// Scale is the scale of the page, between 0.1 and 2.
+	Scale float64 `json:"scale,omitempty"`
+
+	// Background is whether to print background colours and images.
+	Background bool `json:"background,omitempty"`
+
+	// Page is the size of the paper.
+	Page *PrintPage `json:"page,omitempty"`
+
+	// Margin is the size of the margins.
+	Margin *PrintMargin `json:"margin,omitempty"`
+
+	// ShrinkToFit is whether to shrink the page to fit the width of the paper.
+	// If it is nil, the page is shrunk.
+	ShrinkToFit *bool `json:"shrinkToFit,omitempty"`
+
+	// PageRanges are the pages to print, i.e. "1", "3-5" or "7-". If it is
+	// empty, every page is printed.
+	PageRanges []string `json:"pageRanges,omitempty"`
+}
+
+// PrintPage is the size of the paper to print on, in centimetres. A
+// dimension that is nil uses the default of the remote end.
+type PrintPage struct {
+	Width  *float64 `json:"width,omitempty"`
+	Height *float64 `json:"height,omitempty"`
+}
+
+// PrintMargin is the size of each margin of the paper, in centimetres. A
+// margin that is nil uses the default of the remote end (1cm), so a margin of
+// zero must be set explicitly.
+type PrintMargin struct {
+	Top    *float64 `json:"top,omitempty"`
+	Bottom *float64 `json:"bottom,omitempty"`
+	Left   *float64 `json:"left,omitempty"`
+	Right  *float64 `json:"right,omitempty"`
+}
+
+// PrintResponse is the response returned from calling the Print method.
+type PrintResponse struct {
+	State      string
+	EncodedPDF string
+}
+
+// PDFBytes is a helpful function for decoding the base64 encoded PDF.
+func (p *PrintResponse) PDFBytes() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(p.EncodedPDF)
+}
+
+func (p *PrintOptions) validate() error {
+	if p.Orientation != "" && p.Orientation != PortraitOrientation && p.Orientation != LandscapeOrientation {
+		return errors.New("print: invalid orientation option")
+	}
+	if p.Scale != 0 && (p.Scale < 0.1 || p.Scale > 2) {
+		return errors.New("print: invalid scale option, it must be between 0.1 and 2")
+	}
+	if p.Page != nil && (isNotPositive(p.Page.Width) || isNotPositive(p.Page.Height)) {
+		return errors.New("print: invalid page option")
+	}
+	if p.Margin != nil && (isNegative(p.Margin.Top) || isNegative(p.Margin.Bottom) || isNegative(p.Margin.Left) || isNegative(p.Margin.Right)) {
+		return errors.New("print: invalid margin option")
+	}
+	for _, r := range p.PageRanges {
+		if strings.TrimSpace(r) == "" {
+			return errors.New("print: invalid page range option")
+		}
+	}
+
+	return nil
+}
+
+// isNegative checks whether an option is set to a negative value.
+func isNegative(f *float64) bool {
+	return f != nil && *f < 0
+}
+
+// isNotPositive checks whether an option is set to zero or a negative value.
+func isNotPositive(f *float64) bool {
+	return f != nil && *f <= 0
+}
+
+func (s *seleniumWebDriver) Print(opts *PrintOptions) (*PrintResponse, error) {
+	if opts == nil {
+		opts = &PrintOptions{}
+	}
+	err := opts.validate()
+	if err != nil {
+		return nil, err
+	} else if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("Print")
+	}
+
+	url := fmt.Sprintf("%s/session/%s/print", s.seleniumURL, s.sessionID)
+
+	body, err := json.Marshal(opts)
+	if err != nil {
+		return nil, newMarshallingError(err, "Print", opts)
+	}
+
+	resp, err := s.valueRequest(&request{
+		url:           url,
+		method:        "POST",
+		body:          bytes.NewReader(body),
+		callingMethod: "Print",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrintResponse{State: resp.State, EncodedPDF: resp.Value}, nil
+}
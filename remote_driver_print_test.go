@@ -0,0 +1,128 @@
+package goselenium
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_PrintPrint_NoSessionIdCausesError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	_, err := d.Print(nil)
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_PrintPrint_InvalidOptionsResultInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	d.sessionID = "12345"
+
+	zero, negative := 0.0, -1.0
+	invalid := []*PrintOptions{
+		{Orientation: "sideways"},
+		{Scale: 3},
+		{Scale: 0.05},
+		{Page: &PrintPage{Width: &zero}},
+		{Margin: &PrintMargin{Top: &negative}},
+		{PageRanges: []string{"1", ""}},
+	}
+	for _, opts := range invalid {
+		_, err := d.Print(opts)
+		if err == nil || IsSessionIDError(err) {
+			t.Errorf(argumentErrorText)
+		}
+	}
+}
+
+func Test_PrintPrint_CommunicationErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: errors.New("An error! :<"),
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.Print(nil)
+	if err == nil || !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_PrintPrint_UnmarshallingErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "Invalid JSON",
+		errorToReturn: nil,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.Print(nil)
+	if err == nil || !IsUnmarshallingError(err) {
+		t.Errorf(unmarshallingErrorText)
+	}
+}
+
+func Test_PrintPrint_DefaultOptionsAreOmitted(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success", "value": "JVBERi0="}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.Print(nil)
+	if err != nil || api.lastBody != "{}" || !strings.HasSuffix(api.lastURL, "/session/12345/print") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_PrintPrint_OptionsAreSent(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success", "value": "JVBERi0="}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	shrink, width, height, top, left := false, 21.0, 29.7, 2.0, 0.0
+	_, err := d.Print(&PrintOptions{
+		Orientation: LandscapeOrientation,
+		Scale:       0.5,
+		Background:  true,
+		Page:        &PrintPage{Width: &width, Height: &height},
+		Margin:      &PrintMargin{Top: &top, Left: &left},
+		ShrinkToFit: &shrink,
+		PageRanges:  []string{"1", "3-5"},
+	})
+	if err != nil {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	var body map[string]interface{}
+	json.Unmarshal([]byte(api.lastBody), &body)
+	margin := body["margin"].(map[string]interface{})
+	if body["orientation"] != "landscape" || body["scale"] != 0.5 || body["background"] != true ||
+		body["page"].(map[string]interface{})["height"] != 29.7 || margin["top"] != float64(2) ||
+		margin["left"] != float64(0) || margin["bottom"] != nil || body["shrinkToFit"] != false || len(body["pageRanges"].([]interface{})) != 2 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_PrintPrint_PDFCanBeDecoded(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success", "value": "JVBERi0="}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.Print(nil)
+	if err != nil || resp.State != "success" {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	pdf, err := resp.PDFBytes()
+	if err != nil || string(pdf) != "%PDF-" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
@@ -0,0 +1,251 @@
+package goselenium
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// DefaultNearDistance is the distance in CSS pixels that is used by the Near
+// relative locator.
+const DefaultNearDistance = 50
+
+// ByElement wraps an element that has already been found so that it can be
+// used wherever a By is accepted, such as the anchor of a relative locator.
+func ByElement(el Element) By {
+	return elementBy{el}
+}
+
+type elementBy struct {
+	el Element
+}
+
+func (e elementBy) Type() string {
+	return "element"
+}
+
+func (e elementBy) Value() interface{} {
+	return e.el.ID()
+}
+
+func (e elementBy) find(s *seleniumWebDriver, root *seleniumElement) ([]Element, error) {
+	return []Element{e.el}, nil
+}
+
+// RelativeBy is a By implementation that finds elements by their position
+// relative to other elements on the page. It is created by calling ByRelative
+// with the locator of the elements to search for and then narrowed down with
+// one or more of the Above, Below, ToLeftOf, ToRightOf and Near methods.
+//
+// As the filtering is performed by this library using the rect of each
+// element, it works with any remote end. The elements found are sorted by their
+// proximity to the first anchor.
+type RelativeBy struct {
+	base    By
+	filters []relativeFilter
+}
+
+type relativeFilter struct {
+	direction string
+	anchor    By
+	distance  int
+}
+
+// ByRelative creates a relative locator which searches for elements matching
+// base.
+func ByRelative(base By) *RelativeBy {
+	return &RelativeBy{base: base}
+}
+
+// Above narrows the search to elements that are entirely above the anchor.
+func (r *RelativeBy) Above(anchor By) *RelativeBy {
+	return r.with(relativeFilter{direction: "above", anchor: anchor})
+}
+
+// Below narrows the search to elements that are entirely below the anchor.
+func (r *RelativeBy) Below(anchor By) *RelativeBy {
+	return r.with(relativeFilter{direction: "below", anchor: anchor})
+}
+
+// ToLeftOf narrows the search to elements that are entirely to the left of the
+// anchor.
+func (r *RelativeBy) ToLeftOf(anchor By) *RelativeBy {
+	return r.with(relativeFilter{direction: "left of", anchor: anchor})
+}
+
+// ToRightOf narrows the search to elements that are entirely to the right of
+// the anchor.
+func (r *RelativeBy) ToRightOf(anchor By) *RelativeBy {
+	return r.with(relativeFilter{direction: "right of", anchor: anchor})
+}
+
+// Near narrows the search to elements that are no further than
+// DefaultNearDistance pixels away from the anchor.
+func (r *RelativeBy) Near(anchor By) *RelativeBy {
+	return r.NearWithin(anchor, DefaultNearDistance)
+}
+
+// NearWithin works the same way as Near but with a custom distance.
+func (r *RelativeBy) NearWithin(anchor By, distance int) *RelativeBy {
+	return r.with(relativeFilter{direction: "near", anchor: anchor, distance: distance})
+}
+
+func (r *RelativeBy) with(f relativeFilter) *RelativeBy {
+	filters := make([]relativeFilter, len(r.filters), len(r.filters)+1)
+	copy(filters, r.filters)
+
+	return &RelativeBy{
+		base:    r.base,
+		filters: append(filters, f),
+	}
+}
+
+// Type returns the type of the relative locator.
+func (r *RelativeBy) Type() string {
+	return "relative"
+}
+
+// Value returns a description of the base locator and its filters.
+func (r *RelativeBy) Value() interface{} {
+	parts := []string{describeBy(r.base)}
+	for _, f := range r.filters {
+		parts = append(parts, f.direction+" "+describeBy(f.anchor))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (r *RelativeBy) find(s *seleniumWebDriver, root *seleniumElement) ([]Element, error) {
+	elements, err := s.findElements(r.base, root, "FindElements")
+	if err != nil || len(elements) == 0 || len(r.filters) == 0 {
+		return elements, err
+	}
+
+	candidates := make([]positionedElement, len(elements))
+	for i, el := range elements {
+		r, err := elementRect(el)
+		if err != nil {
+			return nil, err
+		}
+		candidates[i] = positionedElement{el, r}
+	}
+
+	var first relativeRect
+	for i, f := range r.filters {
+		anchors, err := s.findElements(f.anchor, root, "FindElements")
+		if err != nil {
+			return nil, err
+		}
+		if len(anchors) == 0 {
+			return nil, newNoSuchElementError("FindElements", s.seleniumURL, f.anchor)
+		}
+
+		anchor, err := elementRect(anchors[0])
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			first = anchor
+		}
+
+		var filtered []positionedElement
+		for _, c := range candidates {
+			if c.el.ID() != anchors[0].ID() && f.matches(c.rect, anchor) {
+				filtered = append(filtered, c)
+			}
+		}
+		candidates = filtered
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return centreDistance(candidates[i].rect, first) < centreDistance(candidates[j].rect, first)
+	})
+
+	found := make([]Element, len(candidates))
+	for i := range candidates {
+		found[i] = candidates[i].el
+	}
+
+	return found, nil
+}
+
+type positionedElement struct {
+	el   Element
+	rect relativeRect
+}
+
+// relativeRect is the position and size of an element. Browsers return
+// fractional values for elements which are not aligned to whole pixels, which
+// Rectangle cannot hold, so relative locators decode the rect of each element
+// into this instead.
+type relativeRect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// elementRect retrieves the rect of an element. Elements which were not found
+// by this library fall back to their Rectangle.
+func elementRect(el Element) (relativeRect, error) {
+	se, ok := el.(*seleniumElement)
+	if !ok || se.wd == nil {
+		resp, err := el.Rectangle()
+		if err != nil {
+			return relativeRect{}, err
+		}
+		r := resp.Rectangle
+
+		return relativeRect{float64(r.X), float64(r.Y), float64(r.Width), float64(r.Height)}, nil
+	}
+
+	return withStaleRecovery(se, "Rectangle", func() (relativeRect, error) {
+		var response struct {
+			Value relativeRect `json:"value"`
+		}
+
+		url := fmt.Sprintf("%s/session/%s/element/%s/rect", se.wd.seleniumURL, se.wd.sessionID, se.ID())
+
+		resp, err := se.wd.apiService.performRequest(url, "GET", nil)
+		if err != nil {
+			return relativeRect{}, newCommunicationError(err, "Rectangle", url, nil)
+		}
+
+		err = json.Unmarshal(resp, &response)
+		if err != nil {
+			return relativeRect{}, newUnmarshallingError(err, "Rectangle", string(resp))
+		}
+
+		return response.Value, nil
+	})
+}
+
+func (f relativeFilter) matches(r relativeRect, anchor relativeRect) bool {
+	switch f.direction {
+	case "above":
+		return r.Y+r.Height <= anchor.Y
+	case "below":
+		return r.Y >= anchor.Y+anchor.Height
+	case "left of":
+		return r.X+r.Width <= anchor.X
+	case "right of":
+		return r.X >= anchor.X+anchor.Width
+	default:
+		return edgeDistance(r, anchor) <= float64(f.distance)
+	}
+}
+
+// edgeDistance is the shortest distance between the edges of two rectangles.
+// Rectangles that overlap have a distance of 0.
+func edgeDistance(a relativeRect, b relativeRect) float64 {
+	dx := math.Max(0, math.Max(b.X-(a.X+a.Width), a.X-(b.X+b.Width)))
+	dy := math.Max(0, math.Max(b.Y-(a.Y+a.Height), a.Y-(b.Y+b.Height)))
+
+	return math.Hypot(dx, dy)
+}
+
+func centreDistance(a relativeRect, b relativeRect) float64 {
+	return math.Hypot((a.X+a.Width/2)-(b.X+b.Width/2), (a.Y+a.Height/2)-(b.Y+b.Height/2))
+}
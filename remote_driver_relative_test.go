@@ -0,0 +1,127 @@
+package goselenium
+
+import (
+	"testing"
+)
+
+func setUpRelativeDriver() *seleniumWebDriver {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/elements": `{
+				"state": "success",
+				"value": [
+					{"element-6066-11e4-a52e-4f735466cecf": "above"},
+					{"element-6066-11e4-a52e-4f735466cecf": "below"},
+					{"element-6066-11e4-a52e-4f735466cecf": "left"},
+					{"element-6066-11e4-a52e-4f735466cecf": "right"},
+					{"element-6066-11e4-a52e-4f735466cecf": "far"},
+					{"element-6066-11e4-a52e-4f735466cecf": "anchor"}
+				]
+			}`,
+			"/element/anchor/rect": `{"value": {"x": 100, "y": 100, "width": 100, "height": 20}}`,
+			"/element/above/rect":  `{"value": {"x": 100, "y": 60, "width": 100, "height": 20}}`,
+			"/element/below/rect":  `{"value": {"x": 100, "y": 130, "width": 100, "height": 20}}`,
+			"/element/left/rect":   `{"value": {"x": 20, "y": 100, "width": 60, "height": 20}}`,
+			"/element/right/rect":  `{"value": {"x": 210, "y": 100, "width": 60, "height": 20}}`,
+			"/element/far/rect":    `{"value": {"x": 900, "y": 900, "width": 10, "height": 10}}`,
+		},
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+	return d
+}
+
+func elementIDs(els []Element) []string {
+	ids := make([]string, len(els))
+	for i := range els {
+		ids[i] = els[i].ID()
+	}
+	return ids
+}
+
+func Test_RelativeByElement_ElementIsReturnedWithoutARequest(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &routedAPIService{})
+	d.sessionID = "12345"
+
+	el, err := d.FindElement(ByElement(newSeleniumElement("1", d)))
+	if err != nil || el.ID() != "1" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_RelativeBy_ElementsAreFilteredByDirection(t *testing.T) {
+	tests := []struct {
+		by       func(anchor By) *RelativeBy
+		expected string
+	}{
+		{ByRelative(ByCSSSelector("input")).Above, "above"},
+		{ByRelative(ByCSSSelector("input")).Below, "below"},
+		{ByRelative(ByCSSSelector("input")).ToLeftOf, "left"},
+		{ByRelative(ByCSSSelector("input")).ToRightOf, "right"},
+	}
+	for _, te := range tests {
+		d := setUpRelativeDriver()
+		anchor := ByElement(newSeleniumElement("anchor", d))
+
+		els, err := d.FindElements(te.by(anchor))
+		if err != nil || len(els) == 0 || els[0].ID() != te.expected {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_RelativeBy_NearElementsAreSortedByProximity(t *testing.T) {
+	d := setUpRelativeDriver()
+	anchor := ByElement(newSeleniumElement("anchor", d))
+
+	els, err := d.FindElements(ByRelative(ByCSSSelector("input")).Near(anchor))
+	ids := elementIDs(els)
+	if err != nil || len(ids) != 4 || ids[0] != "below" || ids[3] != "left" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_RelativeBy_FiltersCanBeCombined(t *testing.T) {
+	d := setUpRelativeDriver()
+	anchor := ByElement(newSeleniumElement("anchor", d))
+	below := ByElement(newSeleniumElement("above", d))
+
+	el, err := d.FindElement(ByRelative(ByCSSSelector("input")).Below(below).ToRightOf(anchor))
+	if err != nil || el.ID() != "right" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_RelativeBy_FractionalRectsAreCompared(t *testing.T) {
+	d := setUpRelativeDriver()
+	api := d.apiService.(*routedAPIService)
+	api.routes["/element/anchor/rect"] = `{"value": {"x": 100.5, "y": 100.25, "width": 99.5, "height": 20.5}}`
+	api.routes["/element/above/rect"] = `{"value": {"x": 100.5, "y": 80, "width": 99.5, "height": 20.5}}`
+	anchor := ByElement(newSeleniumElement("anchor", d))
+
+	// The element above overlaps the anchor by a quarter of a pixel, which
+	// would be lost if the rects were rounded.
+	els, err := d.FindElements(ByRelative(ByCSSSelector("input")).Above(anchor))
+	if err != nil || len(els) != 0 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_RelativeBy_NoSuchElementIsReturnedWhenNothingMatches(t *testing.T) {
+	d := setUpRelativeDriver()
+	anchor := ByElement(newSeleniumElement("far", d))
+
+	_, err := d.FindElement(ByRelative(ByCSSSelector("input")).Below(anchor))
+	comErr, ok := err.(CommunicationError)
+	if !ok || comErr.Response.State != NoSuchElement {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_RelativeBy_ValueDescribesTheLocator(t *testing.T) {
+	r := ByRelative(ByCSSSelector("input")).Above(ByID("email"))
+	if r.Type() != "relative" || r.Value().(string) != "css selector=input above css selector=#email" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
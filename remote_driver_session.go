@@ -19,7 +19,29 @@ type CreateSessionCapabilities struct {
 	AcceptInsecureCerts bool   `json:"acceptSslCerts"`
 	BrowserName         string `json:"browserName"`
 	BrowserVersion      string `json:"browserVersion"`
-	PlatformName        string `json:"platformVersion"`
+	PlatformName        string `json:"platformName"`
+}
+
+// UnmarshalJSON unmarshals the capabilities, reading the platform name from
+// the legacy 'platform' capability if 'platformName' was not returned.
+func (c *CreateSessionCapabilities) UnmarshalJSON(b []byte) error {
+	type capabilities CreateSessionCapabilities
+	var caps struct {
+		capabilities
+		Platform string `json:"platform"`
+	}
+
+	err := json.Unmarshal(b, &caps)
+	if err != nil {
+		return err
+	}
+
+	*c = CreateSessionCapabilities(caps.capabilities)
+	if c.PlatformName == "" {
+		c.PlatformName = caps.Platform
+	}
+
+	return nil
 }
 
 // DeleteSessionResponse is the response returned from the API when the
@@ -64,6 +86,8 @@ func (s *seleniumWebDriver) CreateSession() (*CreateSessionResponse, error) {
 	}
 
 	s.sessionID = response.SessionID
+	s.platformName = response.Capabilities.PlatformName
+	s.setFramePath(FramePath{})
 	return &response, nil
 }
 
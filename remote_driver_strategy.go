@@ -0,0 +1,171 @@
+package goselenium
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// LocatorStrategy resolves the elements for a custom By type on the client.
+// The value is the value of the By being searched for (see ByCustom).
+type LocatorStrategy func(ctx LocatorContext, value interface{}) ([]Element, error)
+
+// LocatorContext is passed to a LocatorStrategy and allows it to search for
+// elements within the same scope as the original request.
+type LocatorContext interface {
+	// Driver returns the web driver that the search is being performed by.
+	Driver() WebDriver
+
+	// Root returns the element that is being searched within. If the whole
+	// document is being searched, it will return nil.
+	Root() Element
+
+	// FindElements finds elements within the root using any other By,
+	// including other custom strategies.
+	FindElements(by By) ([]Element, error)
+
+	// FindElementsByScript executes a script on the currently active page that
+	// must return an array of elements. The root (or null) is passed to the
+	// script as arguments[0], followed by args.
+	FindElementsByScript(script string, args ...interface{}) ([]Element, error)
+}
+
+// reservedStrategies are strategies that are understood by the remote end and
+// cannot be replaced.
+var reservedStrategies = map[string]bool{
+	"css selector":      true,
+	"link text":         true,
+	"partial link text": true,
+	"tag name":          true,
+	"xpath":             true,
+	"index":             true,
+}
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]LocatorStrategy{}
+)
+
+// RegisterLocatorStrategy registers a strategy which will be used to find
+// elements whenever a By with a Type() of name is searched for. Registering a
+// name twice replaces the previous strategy. An error is returned if the name
+// is empty or is one of the strategies defined by the W3C specification.
+func RegisterLocatorStrategy(name string, strategy LocatorStrategy) error {
+	if name == "" || strategy == nil {
+		return errors.New("registerlocatorstrategy: invalid name or strategy argument")
+	}
+	if reservedStrategies[name] {
+		return fmt.Errorf("registerlocatorstrategy: %s is a reserved strategy", name)
+	}
+
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+
+	strategies[name] = strategy
+	return nil
+}
+
+// UnregisterLocatorStrategy removes a previously registered strategy.
+func UnregisterLocatorStrategy(name string) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+
+	delete(strategies, name)
+}
+
+// LookupLocatorStrategy retrieves a registered strategy by its name.
+func LookupLocatorStrategy(name string) (LocatorStrategy, bool) {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+
+	strategy, ok := strategies[name]
+	return strategy, ok
+}
+
+// ScriptLocatorStrategy creates a strategy which finds elements by executing a
+// script. The script is passed the root element (or null) as arguments[0] and
+// the value of the By as arguments[1], and must return an array of elements.
+// For example, to find elements by their data-qa attribute:
+//
+//	var root = arguments[0] || document;
+//	return root.querySelectorAll('[data-qa="' + arguments[1] + '"]');
+func ScriptLocatorStrategy(script string) LocatorStrategy {
+	return func(ctx LocatorContext, value interface{}) ([]Element, error) {
+		return ctx.FindElementsByScript(script, value)
+	}
+}
+
+// ComposedLocatorStrategy creates a strategy which translates the value of a
+// custom By into another By (which may itself be custom).
+func ComposedLocatorStrategy(f func(value interface{}) By) LocatorStrategy {
+	return func(ctx LocatorContext, value interface{}) ([]Element, error) {
+		return ctx.FindElements(f(value))
+	}
+}
+
+// ByCustom creates a By for a strategy registered with
+// RegisterLocatorStrategy.
+func ByCustom(name string, value interface{}) By {
+	return by{
+		t:     name,
+		value: value,
+	}
+}
+
+// clientFinder returns the finder for a By that is resolved on the client,
+// either because it implements finder or because it has a registered strategy.
+func clientFinder(by By) (finder, bool) {
+	if f, ok := by.(finder); ok {
+		return f, true
+	}
+	if strategy, ok := LookupLocatorStrategy(by.Type()); ok {
+		return strategyFinder{strategy: strategy, value: by.Value()}, true
+	}
+
+	return nil, false
+}
+
+type strategyFinder struct {
+	strategy LocatorStrategy
+	value    interface{}
+}
+
+func (f strategyFinder) find(s *seleniumWebDriver, root *seleniumElement) ([]Element, error) {
+	return f.strategy(&locatorContext{wd: s, root: root}, f.value)
+}
+
+type locatorContext struct {
+	wd   *seleniumWebDriver
+	root *seleniumElement
+}
+
+func (l *locatorContext) Driver() WebDriver {
+	return l.wd
+}
+
+func (l *locatorContext) Root() Element {
+	if l.root == nil {
+		return nil
+	}
+
+	return l.root
+}
+
+func (l *locatorContext) FindElements(by By) ([]Element, error) {
+	if by == nil || by.Type() == "index" {
+		return nil, errors.New("findelements: invalid by argument")
+	}
+
+	return l.wd.findElements(by, l.root, "FindElements")
+}
+
+func (l *locatorContext) FindElementsByScript(script string, args ...interface{}) ([]Element, error) {
+	var r interface{}
+	if l.root != nil {
+		r = l.root
+	}
+
+	scriptArgs := append([]interface{}{r}, args...)
+
+	return l.wd.scriptElementsRequest(script, scriptArgs, "FindElements")
+}
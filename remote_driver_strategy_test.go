@@ -0,0 +1,144 @@
+package goselenium
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_StrategyRegister_ReservedAndEmptyNamesResultInError(t *testing.T) {
+	strategy := func(ctx LocatorContext, value interface{}) ([]Element, error) {
+		return nil, nil
+	}
+	for _, name := range []string{"", "css selector", "xpath", "index"} {
+		if err := RegisterLocatorStrategy(name, strategy); err == nil {
+			t.Errorf(argumentErrorText)
+		}
+	}
+	if err := RegisterLocatorStrategy("data-qa", nil); err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_StrategyRegister_StrategyCanBeLookedUpAndUnregistered(t *testing.T) {
+	strategy := func(ctx LocatorContext, value interface{}) ([]Element, error) {
+		return nil, nil
+	}
+	if err := RegisterLocatorStrategy("test-lookup", strategy); err != nil {
+		t.Errorf(correctResponseErrorText)
+	}
+	if _, ok := LookupLocatorStrategy("test-lookup"); !ok {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	UnregisterLocatorStrategy("test-lookup")
+	if _, ok := LookupLocatorStrategy("test-lookup"); ok {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_StrategyFindElement_RegisteredStrategyIsUsed(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: errors.New("An error :<"),
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	var received interface{}
+	RegisterLocatorStrategy("test-static", func(ctx LocatorContext, value interface{}) ([]Element, error) {
+		received = value
+		if ctx.Root() != nil || ctx.Driver() != d {
+			return nil, errors.New("unexpected context")
+		}
+		return []Element{newSeleniumElement("static", d)}, nil
+	})
+	defer UnregisterLocatorStrategy("test-static")
+
+	el, err := d.FindElement(ByCustom("test-static", "value"))
+	if err != nil || el.ID() != "static" || received != "value" || api.lastURL != "" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_StrategyFindElement_NoSuchElementIsReturnedWhenNothingMatches(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	d.sessionID = "12345"
+
+	RegisterLocatorStrategy("test-empty", func(ctx LocatorContext, value interface{}) ([]Element, error) {
+		return nil, nil
+	})
+	defer UnregisterLocatorStrategy("test-empty")
+
+	_, err := d.FindElement(ByCustom("test-empty", "value"))
+	comErr, ok := err.(CommunicationError)
+	if !ok || comErr.Response.State != NoSuchElement {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_StrategyScriptLocator_ScriptIsExecutedWithTheValue(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": [{"element-6066-11e4-a52e-4f735466cecf": "1"}]
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	RegisterLocatorStrategy("test-script", ScriptLocatorStrategy("return [];"))
+	defer UnregisterLocatorStrategy("test-script")
+
+	els, err := d.FindElements(ByCustom("test-script", "login"))
+	if err != nil || len(els) != 1 || els[0].ID() != "1" {
+		t.Errorf(correctResponseErrorText)
+	}
+	if !strings.Contains(api.lastBody, `"args":[null,"login"]`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_StrategyComposedLocator_ValueIsTranslatedIntoAnotherBy(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": [{"element-6066-11e4-a52e-4f735466cecf": "1"}]
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	RegisterLocatorStrategy("test-composed", ComposedLocatorStrategy(func(value interface{}) By {
+		return ByCSSSelector(`[data-qa="` + value.(string) + `"]`)
+	}))
+	defer UnregisterLocatorStrategy("test-composed")
+
+	el, err := d.FindElement(ByCustom("test-composed", "login"))
+	if err != nil || el.ID() != "1" {
+		t.Errorf(correctResponseErrorText)
+	}
+	if !strings.HasSuffix(api.lastURL, "/elements") ||
+		!strings.Contains(api.lastBody, `"using":"css selector"`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_StrategyWaits_CustomStrategiesCanBeWaitedFor(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	d.sessionID = "12345"
+
+	RegisterLocatorStrategy("test-wait", func(ctx LocatorContext, value interface{}) ([]Element, error) {
+		return []Element{newSeleniumElement("1", d)}, nil
+	})
+	defer UnregisterLocatorStrategy("test-wait")
+
+	if ok, err := UntilElementPresent(ByCustom("test-wait", "value")).Check(d); !ok || err != nil {
+		t.Errorf(correctResponseErrorText)
+	}
+}
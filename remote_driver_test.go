@@ -1,7 +1,10 @@
 package goselenium
 
 import (
+	"encoding/json"
+	"errors"
 	"io"
+	"io/ioutil"
 	"strings"
 	"testing"
 )
@@ -32,11 +35,80 @@ type testableAPIService struct {
 	jsonToReturn  string
 	errorToReturn error
 	bodyNilError  error
+	lastURL       string
+	lastBody      string
 }
 
 func (t *testableAPIService) performRequest(url string, method string, body io.Reader) ([]byte, error) {
-	json := []byte(t.jsonToReturn)
-	return json, t.errorToReturn
+	t.lastURL = url
+	t.lastBody = ""
+	if body != nil {
+		b, _ := ioutil.ReadAll(body)
+		t.lastBody = string(b)
+	}
+
+	return asInstalledPinnedScript(t.lastBody, []byte(t.jsonToReturn)), t.errorToReturn
+}
+
+// asInstalledPinnedScript wraps the value of a response to a pinned script as
+// though the script was already installed in the page, so that tests of the
+// scripts used internally (such as the locator script) can return the value
+// of the script itself. pageAPIService imitates installing them instead.
+func asInstalledPinnedScript(body string, resp []byte) []byte {
+	if !strings.Contains(body, "__goselenium_pinned") {
+		return resp
+	}
+
+	var r map[string]json.RawMessage
+	if json.Unmarshal(resp, &r) != nil {
+		return resp
+	}
+	value := r["value"]
+	if len(value) == 0 {
+		value = json.RawMessage("null")
+	}
+	r["value"] = json.RawMessage(`{"installed": true, "value": ` + string(value) + `}`)
+
+	b, _ := json.Marshal(r)
+	return b
+}
+
+// routedAPIService returns the JSON of the route whose key is the longest
+// suffix of the requested URL, allowing tests to cover methods which make
+// several requests.
+type routedAPIService struct {
+	routes   map[string]string
+	errors   map[string]error
+	requests []string
+	bodies   []string
+}
+
+func (r *routedAPIService) performRequest(url string, method string, body io.Reader) ([]byte, error) {
+	r.requests = append(r.requests, method+" "+url)
+
+	var b []byte
+	if body != nil {
+		b, _ = ioutil.ReadAll(body)
+	}
+	r.bodies = append(r.bodies, string(b))
+
+	for suffix, err := range r.errors {
+		if strings.HasSuffix(url, suffix) {
+			return nil, err
+		}
+	}
+
+	var match string
+	for suffix := range r.routes {
+		if strings.HasSuffix(url, suffix) && len(suffix) > len(match) {
+			match = suffix
+		}
+	}
+	if match == "" {
+		return nil, errors.New("no route for " + url)
+	}
+
+	return asInstalledPinnedScript(string(b), []byte(r.routes[match])), nil
 }
 
 func Test_NewSelenium_WebDriverCreatesErrorIfSeleniumURLIsInvalid(t *testing.T) {
@@ -105,3 +177,54 @@ func Test_ByByIndex_CorrectIndexReturnsAsExpected(t *testing.T) {
 		}
 	}
 }
+
+func Test_ByByID_IsTranslatedToEscapedCSSSelector(t *testing.T) {
+	tests := []struct {
+		id       string
+		expected string
+	}{
+		{"login", "#login"},
+		{"user.name", `#user\.name`},
+		{"1st", `#\31 st`},
+		{"-", `#\-`},
+		{"a:b[c]", `#a\:b\[c\]`},
+	}
+	for _, te := range tests {
+		r := ByID(te.id)
+		if r.Type() != "css selector" || r.Value().(string) != te.expected {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_ByByName_IsTranslatedToEscapedCSSSelector(t *testing.T) {
+	r := ByName(`q"uery`)
+	if r.Type() != "css selector" || r.Value().(string) != `*[name="q\"uery"]` {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ByByClassName_CompoundClassNamesAreJoined(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"btn", ".btn"},
+		{"btn  primary", ".btn.primary"},
+		{"col-md-6", ".col-md-6"},
+		{"w-1/2", `.w-1\/2`},
+	}
+	for _, te := range tests {
+		r := ByClassName(te.name)
+		if r.Type() != "css selector" || r.Value().(string) != te.expected {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_ByByTagName_IsTranslatedToCSSSelector(t *testing.T) {
+	r := ByTagName("div")
+	if r.Type() != "css selector" || r.Value().(string) != "div" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
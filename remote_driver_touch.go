@@ -0,0 +1,177 @@
+package goselenium
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultLongPressDuration is how long a long press lasts when no duration is
+// passed to LongPress.
+const DefaultLongPressDuration = time.Second
+
+// The distance in pixels between fingers that start or finish a gesture at the
+// same point, so that they remain two separate touches.
+const (
+	fingerSpacing = 20
+	pinchGap      = 10
+)
+
+// TouchTarget is where a touch gesture takes place. It is created by calling
+// AtPoint, AtElement or AtElementOffset.
+//
+// Gestures are performed with touch pointers, so the browser must support
+// touch input (i.e. AndroidBrowser, IPhoneBrowser or a desktop browser which
+// is emulating a mobile device).
+type TouchTarget struct {
+	origin Origin
+	x      int
+	y      int
+}
+
+// AtPoint targets a point relative to the top left of the viewport.
+func AtPoint(x int, y int) TouchTarget {
+	return TouchTarget{origin: ViewportOrigin, x: x, y: y}
+}
+
+// AtElement targets the centre of an element.
+func AtElement(el Element) TouchTarget {
+	return AtElementOffset(el, 0, 0)
+}
+
+// AtElementOffset targets a point relative to the centre of an element.
+func AtElementOffset(el Element, x int, y int) TouchTarget {
+	if el == nil {
+		return TouchTarget{}
+	}
+
+	return TouchTarget{origin: ElementOrigin(el), x: x, y: y}
+}
+
+func (t TouchTarget) valid() bool {
+	return t.origin.el != nil || t.origin.name != ""
+}
+
+// fingers returns n touch pointers (finger1 to fingerN) which have been
+// synchronised with every other input source so that they start together.
+func (a *Actions) fingers(n int) []*PointerInput {
+	fingers := make([]*PointerInput, n)
+	for i := range fingers {
+		fingers[i] = a.Pointer(fmt.Sprintf("finger%d", i+1), TouchPointer)
+	}
+	a.sync()
+
+	return fingers
+}
+
+// Tap touches the target with a single finger.
+func (a *Actions) Tap(target TouchTarget) *Actions {
+	return a.MultiTap(target, 1)
+}
+
+// MultiTap touches the target with a number of fingers at the same time. The
+// fingers are spread horizontally around the target.
+func (a *Actions) MultiTap(target TouchTarget, fingers int) *Actions {
+	if !target.valid() {
+		a.setErr(errors.New("multitap: invalid target argument"))
+		return a
+	} else if fingers < 1 {
+		a.setErr(errors.New("multitap: invalid fingers argument"))
+		return a
+	}
+
+	start := target.x - (fingers-1)*fingerSpacing/2
+	for i, f := range a.fingers(fingers) {
+		f.Move(target.origin, start+i*fingerSpacing, target.y, 0).
+			Down(LeftMouseButton).
+			Up(LeftMouseButton)
+	}
+
+	return a
+}
+
+// LongPress touches the target and holds for the duration passed in. If the
+// duration is zero, DefaultLongPressDuration is used.
+func (a *Actions) LongPress(target TouchTarget, d time.Duration) *Actions {
+	if !target.valid() {
+		a.setErr(errors.New("longpress: invalid target argument"))
+		return a
+	}
+	if d == 0 {
+		d = DefaultLongPressDuration
+	}
+
+	a.fingers(1)[0].
+		Move(target.origin, target.x, target.y, 0).
+		Down(LeftMouseButton).
+		Pause(d).
+		Up(LeftMouseButton)
+
+	return a
+}
+
+// Swipe touches the target and moves by x and y over the duration passed in
+// before lifting the finger. A negative y swipes up, which scrolls the page
+// down.
+func (a *Actions) Swipe(from TouchTarget, x int, y int, d time.Duration) *Actions {
+	if !from.valid() {
+		a.setErr(errors.New("swipe: invalid target argument"))
+		return a
+	}
+
+	a.fingers(1)[0].
+		Move(from.origin, from.x, from.y, 0).
+		Down(LeftMouseButton).
+		Move(PointerOrigin, x, y, d).
+		Up(LeftMouseButton)
+
+	return a
+}
+
+// Pinch touches either side of the target with two fingers which are
+// distance pixels apart and moves them together over the duration passed in,
+// which usually zooms out.
+func (a *Actions) Pinch(target TouchTarget, distance int, d time.Duration) *Actions {
+	if !target.valid() {
+		a.setErr(errors.New("pinch: invalid target argument"))
+		return a
+	} else if distance <= pinchGap {
+		a.setErr(fmt.Errorf("pinch: invalid distance argument, it must be greater than %d", pinchGap))
+		return a
+	}
+
+	a.pinch(target, distance/2, pinchGap/2, d)
+	return a
+}
+
+// Zoom touches the target with two fingers and moves them apart until they
+// are distance pixels apart over the duration passed in, which usually zooms
+// in.
+func (a *Actions) Zoom(target TouchTarget, distance int, d time.Duration) *Actions {
+	if !target.valid() {
+		a.setErr(errors.New("zoom: invalid target argument"))
+		return a
+	} else if distance <= pinchGap {
+		a.setErr(fmt.Errorf("zoom: invalid distance argument, it must be greater than %d", pinchGap))
+		return a
+	}
+
+	a.pinch(target, pinchGap/2, distance/2, d)
+	return a
+}
+
+// pinch moves two fingers horizontally from start to end pixels either side
+// of the target.
+func (a *Actions) pinch(target TouchTarget, start int, end int, d time.Duration) {
+	for i, f := range a.fingers(2) {
+		direction := 1
+		if i == 0 {
+			direction = -1
+		}
+
+		f.Move(target.origin, target.x+direction*start, target.y, 0).
+			Down(LeftMouseButton).
+			Move(target.origin, target.x+direction*end, target.y, d).
+			Up(LeftMouseButton)
+	}
+}
@@ -0,0 +1,118 @@
+package goselenium
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func actionAt(source map[string]interface{}, i int) map[string]interface{} {
+	return source["actions"].([]interface{})[i].(map[string]interface{})
+}
+
+func Test_Touch_InvalidGesturesResultInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	d.sessionID = "12345"
+
+	invalid := []*Actions{
+		NewActions().Tap(AtElement(nil)),
+		NewActions().MultiTap(AtPoint(0, 0), 0),
+		NewActions().LongPress(TouchTarget{}, 0),
+		NewActions().Swipe(AtElement(nil), 0, 100, 0),
+		NewActions().Pinch(AtPoint(100, 100), 5, 0),
+		NewActions().Zoom(AtPoint(100, 100), 0, 0),
+	}
+	for _, a := range invalid {
+		_, err := d.PerformActions(a)
+		if err == nil {
+			t.Errorf(argumentErrorText)
+		}
+	}
+}
+
+func Test_Touch_SwipeMovesFromTheTarget(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	s := sequences(t, NewActions().Swipe(AtElement(newSeleniumElement("list", d)), 0, -300, time.Second))
+	if len(s) != 1 || s[0]["id"] != "finger1" {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if s[0]["parameters"].(map[string]interface{})["pointerType"] != "touch" {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	start, swipe := actionAt(s[0], 0), actionAt(s[0], 2)
+	if start["origin"].(map[string]interface{})[webElementIdentifier] != "list" {
+		t.Errorf(correctResponseErrorText)
+	}
+	if swipe["origin"] != "pointer" || swipe["y"] != float64(-300) || swipe["duration"] != float64(1000) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Touch_LongPressHoldsForTheDuration(t *testing.T) {
+	s := sequences(t, NewActions().LongPress(AtPoint(10, 20), 0))
+
+	types := strings.Join(actionTypes(s[0]), ",")
+	if types != "pointerMove,pointerDown,pause,pointerUp" {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if actionAt(s[0], 2)["duration"] != float64(1000) || actionAt(s[0], 0)["x"] != float64(10) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Touch_MultiTapUsesConcurrentFingers(t *testing.T) {
+	s := sequences(t, NewActions().MultiTap(AtPoint(100, 100), 3))
+	if len(s) != 3 {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	expected := []float64{80, 100, 120}
+	for i, finger := range s {
+		if finger["id"] != "finger"+string(rune('1'+i)) {
+			t.Errorf(correctResponseErrorText)
+		}
+		if strings.Join(actionTypes(finger), ",") != "pointerMove,pointerDown,pointerUp" {
+			t.Errorf(correctResponseErrorText)
+		}
+		if actionAt(finger, 0)["x"] != expected[i] {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_Touch_PinchAndZoomMoveFingersInOppositeDirections(t *testing.T) {
+	s := sequences(t, NewActions().Pinch(AtPoint(200, 300), 100, 0).Zoom(AtPoint(200, 300), 100, 0))
+	if len(s) != 2 {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	left, right := s[0], s[1]
+	if len(actionTypes(left)) != 8 || len(actionTypes(right)) != 8 {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	// Pinch starts the fingers apart and moves them together.
+	if actionAt(left, 0)["x"] != float64(150) || actionAt(left, 2)["x"] != float64(195) ||
+		actionAt(right, 0)["x"] != float64(250) || actionAt(right, 2)["x"] != float64(205) {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	// Zoom does the opposite.
+	if actionAt(left, 4)["x"] != float64(195) || actionAt(left, 6)["x"] != float64(150) ||
+		actionAt(right, 4)["x"] != float64(205) || actionAt(right, 6)["x"] != float64(250) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Touch_GesturesWaitForPreviousActions(t *testing.T) {
+	s := sequences(t, NewActions().Tap(AtPoint(0, 0)).MultiTap(AtPoint(0, 0), 2))
+	if len(s) != 2 {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	if strings.Join(actionTypes(s[1]), ",") != "pause,pause,pause,pointerMove,pointerDown,pointerUp" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
@@ -0,0 +1,254 @@
+package goselenium
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultWaitInterval is how long to wait between checking a condition when
+// no interval is passed to WaitContext, WaitForValue or WaitForElement.
+const DefaultWaitInterval = 100 * time.Millisecond
+
+// Condition is something that can be waited for with Wait, WaitContext or a
+// FluentWait. Check is called repeatedly until it returns true; WaitContext
+// treats any error that it returns as the condition not being satisfied yet
+// and reports it in the WaitTimeoutError if the wait times out (FluentWait
+// only does so for the error states that it ignores). Description is used in
+// that error to describe what was being waited for.
+//
+// Until implements Condition, so existing Until functions can be passed to
+// any of them. Conditions with readable descriptions (such as UntilTitleIs
+// and UntilElementVisible) are provided, and NewCondition creates others.
+type Condition interface {
+	Description() string
+	Check(w WebDriver) (bool, error)
+}
+
+// Description describes an Until function, which is only known to be a
+// custom condition.
+func (u Until) Description() string {
+	return "custom condition"
+}
+
+// Check calls the Until function.
+func (u Until) Check(w WebDriver) (bool, error) {
+	return u(w), nil
+}
+
+func (s *seleniumWebDriver) WaitContext(ctx context.Context, c Condition, interval time.Duration) error {
+	if c == nil {
+		return errors.New("waitcontext: invalid condition argument")
+	}
+
+	_, err := poll(ctx, "WaitContext", c.Description(), interval, false, retryValid, func() (struct{}, bool, error) {
+		ok, err := c.Check(s)
+		return struct{}{}, ok, err
+	})
+
+	return err
+}
+
+// WaitForValue waits until f returns true and then returns the value that it
+// returned alongside. The value returned whilst f is not satisfied is
+// reported as the LastValue of the WaitTimeoutError if the context is done
+// first, so f can return the state that it observed (such as the current
+// URL). As with WaitContext, errors returned by f are treated as the
+// condition not being satisfied yet.
+//
+//	count, err := goselenium.WaitForValue(ctx, d, "at least 10 results", 0,
+//		func(w goselenium.WebDriver) (int, bool, error) {
+//			results, err := w.FindElements(goselenium.ByCSSSelector(".result"))
+//			return len(results), len(results) >= 10, err
+//		})
+func WaitForValue[T any](ctx context.Context, w WebDriver, description string, interval time.Duration, f func(w WebDriver) (T, bool, error)) (T, error) {
+	if w == nil || f == nil {
+		var zero T
+		return zero, errors.New("waitforvalue: invalid argument")
+	}
+
+	return poll(ctx, "WaitForValue", description, interval, true, retryValid, func() (T, bool, error) {
+		return f(w)
+	})
+}
+
+// WaitForElement waits until an element matching by is found and returns it.
+// If the context is done first, the last error returned by FindElement is
+// the LastErr of the WaitTimeoutError.
+func WaitForElement(ctx context.Context, w WebDriver, by By, interval time.Duration) (Element, error) {
+	if w == nil || by == nil {
+		return nil, errors.New("waitforelement: invalid argument")
+	}
+
+	return poll(ctx, "WaitForElement", "element "+describeBy(by), interval, false, retryValid, func() (Element, bool, error) {
+		el, err := w.FindElement(by)
+		return el, err == nil, err
+	})
+}
+
+// retryValid is used by waits which treat every error as the condition not
+// being satisfied yet, unless the condition itself is invalid.
+func retryValid(err error) bool {
+	_, invalid := err.(invalidConditionError)
+	return !invalid
+}
+
+// poll calls check until it is satisfied or until the context is done, in
+// which case a WaitTimeoutError is returned. If check returns an error which
+// retry returns false for, it is returned straight away. The last value
+// returned by check is only reported in the WaitTimeoutError if trackValue is
+// true, as waits for a Condition have no value worth reporting.
+func poll[T any](ctx context.Context, method string, description string, interval time.Duration, trackValue bool, retry func(err error) bool, check func() (T, bool, error)) (T, error) {
+	var zero T
+	if ctx == nil {
+		return zero, errors.New(strings.ToLower(method) + ": invalid context argument")
+	}
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+
+	start := time.Now()
+	attempts := 0
+	var lastErr error
+	var lastValue interface{}
+	for {
+		if ctx.Err() != nil {
+			return zero, newWaitTimeoutError(method, description, attempts, time.Since(start), lastErr, lastValue, ctx.Err())
+		}
+
+		attempts++
+		value, ok, err := check()
+		if ok && err == nil {
+			return value, nil
+		}
+		if err != nil && !retry(err) {
+			return zero, err
+		}
+		if err != nil {
+			lastErr = err
+		} else if trackValue {
+			lastValue = value
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, newWaitTimeoutError(method, description, attempts, time.Since(start), lastErr, lastValue, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// DefaultWaitTimeout is how long a FluentWait waits for when no timeout has
+// been set.
+const DefaultWaitTimeout = 10 * time.Second
+
+// FluentWait is a reusable wait configuration which is created by calling
+// NewFluentWait and then configured with its methods before calling Until:
+//
+//	err := goselenium.NewFluentWait(d).
+//		WithTimeout(5 * time.Second).
+//		PollingEvery(250 * time.Millisecond).
+//		Ignoring(goselenium.ElementNotInteractable).
+//		WithMessage("the basket was not updated").
+//		Until(goselenium.UntilTextContains(goselenium.ByID("basket"), "1 item"))
+//
+// Unlike WaitContext, errors returned whilst checking the condition end the
+// wait straight away unless they were returned by the remote end with one of
+// the ignored states. NoSuchElement and StaleElementReference are ignored by
+// default, as they are expected whilst a page is rendering.
+type FluentWait struct {
+	w        WebDriver
+	timeout  time.Duration
+	interval time.Duration
+	ignored  []string
+	message  string
+}
+
+// NewFluentWait creates a FluentWait for a driver which waits for
+// DefaultWaitTimeout, checking every DefaultWaitInterval and ignoring
+// NoSuchElement and StaleElementReference errors.
+func NewFluentWait(w WebDriver) *FluentWait {
+	return &FluentWait{
+		w:        w,
+		timeout:  DefaultWaitTimeout,
+		interval: DefaultWaitInterval,
+		ignored:  []string{NoSuchElement, StaleElementReference},
+	}
+}
+
+// WithTimeout sets how long to wait for. A timeout of zero or less means
+// that only the context passed to UntilContext ends the wait.
+func (f *FluentWait) WithTimeout(timeout time.Duration) *FluentWait {
+	f.timeout = timeout
+	return f
+}
+
+// PollingEvery sets how long to wait between checking the condition.
+func (f *FluentWait) PollingEvery(interval time.Duration) *FluentWait {
+	f.interval = interval
+	return f
+}
+
+// Ignoring adds error states (such as ElementNotInteractable) to those which are
+// treated as the condition not being satisfied yet rather than failing the
+// wait.
+func (f *FluentWait) Ignoring(states ...string) *FluentWait {
+	f.ignored = append(f.ignored, states...)
+	return f
+}
+
+// WithMessage sets a message which is included in the WaitTimeoutError if
+// the wait times out.
+func (f *FluentWait) WithMessage(message string) *FluentWait {
+	f.message = message
+	return f
+}
+
+// Until waits until the condition is satisfied or the timeout is reached.
+func (f *FluentWait) Until(c Condition) error {
+	return f.UntilContext(context.Background(), c)
+}
+
+// UntilContext waits until the condition is satisfied, the timeout is reached
+// or the context is done.
+func (f *FluentWait) UntilContext(ctx context.Context, c Condition) error {
+	if f.w == nil {
+		return errors.New("fluentwait: invalid driver argument")
+	} else if c == nil {
+		return errors.New("fluentwait: invalid condition argument")
+	} else if ctx == nil {
+		return errors.New("fluentwait: invalid context argument")
+	}
+
+	if f.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.timeout)
+		defer cancel()
+	}
+
+	_, err := poll(ctx, "FluentWait", c.Description(), f.interval, false, f.ignores, func() (struct{}, bool, error) {
+		ok, err := c.Check(f.w)
+		return struct{}{}, ok, err
+	})
+	if timeoutErr, ok := err.(WaitTimeoutError); ok {
+		timeoutErr.Message = f.message
+		return timeoutErr
+	}
+
+	return err
+}
+
+// ignores checks whether an error was returned with one of the ignored
+// states.
+func (f *FluentWait) ignores(err error) bool {
+	for _, state := range f.ignored {
+		if hasErrorState(err, state) {
+			return true
+		}
+	}
+
+	return false
+}
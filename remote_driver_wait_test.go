@@ -0,0 +1,312 @@
+package goselenium
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingCondition is satisfied once it has been checked a number of times,
+// returning err until then.
+type countingCondition struct {
+	after  int
+	checks int
+	err    error
+}
+
+func (c *countingCondition) Description() string {
+	return "the counter"
+}
+
+func (c *countingCondition) Check(w WebDriver) (bool, error) {
+	c.checks++
+	if c.checks >= c.after {
+		return true, nil
+	}
+
+	return false, c.err
+}
+
+// lateElementAPIService returns a 'no such element' error until the element
+// has been requested a number of times.
+type lateElementAPIService struct {
+	after    int
+	requests int
+}
+
+func (l *lateElementAPIService) performRequest(url string, method string, body io.Reader) ([]byte, error) {
+	l.requests++
+	if l.requests < l.after {
+		return nil, &requestError{State: NoSuchElement, Value: requestErrorValue{Message: "no such element"}}
+	}
+
+	return []byte(`{"state": "success", "value": {"element": "late"}}`), nil
+}
+
+/*
+	WaitContext tests
+*/
+func Test_WaitContext_InvalidArgumentsResultInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	if d.WaitContext(context.Background(), nil, 0) == nil {
+		t.Errorf(argumentErrorText)
+	}
+	if d.WaitContext(nil, &countingCondition{}, 0) == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_WaitContext_ReturnsOnceTheConditionIsSatisfied(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	c := &countingCondition{after: 3}
+	err := d.WaitContext(context.Background(), c, time.Millisecond)
+	if err != nil || c.checks != 3 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitContext_AcceptsUntilFunctions(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success", "value": "https://www.google.com"}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	err := d.WaitContext(context.Background(), UntilURLIs("https://www.google.com"), time.Millisecond)
+	if err != nil {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitContext_TimeoutErrorDescribesTheFailure(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	lastErr := newNoSuchElementError("FindElement", "", ByCSSSelector(".missing"))
+	c := &countingCondition{after: 1000, err: lastErr}
+	err := d.WaitContext(ctx, c, 5*time.Millisecond)
+	if !IsWaitTimeoutError(err) {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	timeoutErr := err.(WaitTimeoutError)
+	if timeoutErr.Description != "the counter" || timeoutErr.Attempts != c.checks || timeoutErr.Attempts < 2 {
+		t.Errorf(correctResponseErrorText)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) || !IsCommunicationError(timeoutErr.LastErr) {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	var comErr CommunicationError
+	if !errors.As(err, &comErr) || comErr.Response.State != NoSuchElement {
+		t.Errorf(correctResponseErrorText)
+	}
+	if !strings.Contains(err.Error(), "the counter") || !strings.Contains(err.Error(), ".missing") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitContext_ElementPresenceReportsTheLastError(t *testing.T) {
+	api := &lateElementAPIService{after: 1000}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := d.WaitContext(ctx, UntilElementPresent(ByCSSSelector(".late")), time.Millisecond)
+	if !IsWaitTimeoutError(err) || !strings.Contains(err.Error(), "element css selector=.late to be present") {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	var comErr CommunicationError
+	if !errors.As(err, &comErr) || comErr.Response.State != NoSuchElement {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitContext_TimeoutErrorHasNoLastValue(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := d.WaitContext(ctx, Until(func(w WebDriver) bool { return false }), time.Millisecond)
+	if !IsWaitTimeoutError(err) || err.(WaitTimeoutError).LastValue != nil {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if strings.Contains(err.Error(), "last value") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitContext_CancelledContextResultsInTimeout(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := &countingCondition{after: 1}
+	err := d.WaitContext(ctx, c, 0)
+	if !IsWaitTimeoutError(err) || !errors.Is(err, context.Canceled) || c.checks != 0 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+	WaitForValue tests
+*/
+func Test_WaitForValue_ReturnsTheValue(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	count := 0
+	v, err := WaitForValue(context.Background(), d, "five results", time.Millisecond, func(w WebDriver) (int, bool, error) {
+		count++
+		return count, count == 5, nil
+	})
+	if err != nil || v != 5 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitForValue_TimeoutErrorContainsTheLastValue(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := WaitForValue(ctx, d, "the checkout page", time.Millisecond, func(w WebDriver) (string, bool, error) {
+		return "/basket", false, nil
+	})
+	if !IsWaitTimeoutError(err) || err.(WaitTimeoutError).LastValue != "/basket" {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if !strings.Contains(err.Error(), "last value: /basket") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+	WaitForElement tests
+*/
+func Test_WaitForElement_InvalidArgumentsResultInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	_, err := WaitForElement(context.Background(), d, nil, 0)
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_WaitForElement_ReturnsTheElementOnceFound(t *testing.T) {
+	api := &lateElementAPIService{after: 3}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	el, err := WaitForElement(context.Background(), d, ByCSSSelector(".late"), time.Millisecond)
+	if err != nil || el.ID() != "late" || api.requests != 3 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitForElement_TimeoutErrorContainsTheLastError(t *testing.T) {
+	api := &lateElementAPIService{after: 1000}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := WaitForElement(ctx, d, ByCSSSelector(".late"), time.Millisecond)
+	if !IsWaitTimeoutError(err) || !strings.Contains(err.Error(), "element css selector=.late") {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	var comErr CommunicationError
+	if !errors.As(err, &comErr) || comErr.Response.State != NoSuchElement {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+	FluentWait tests
+*/
+func Test_FluentWait_InvalidArgumentsResultInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	if NewFluentWait(nil).Until(&countingCondition{}) == nil {
+		t.Errorf(argumentErrorText)
+	}
+	if NewFluentWait(d).Until(nil) == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_FluentWait_ReturnsOnceTheConditionIsSatisfied(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	c := &countingCondition{after: 3}
+	err := NewFluentWait(d).PollingEvery(time.Millisecond).Until(c)
+	if err != nil || c.checks != 3 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_FluentWait_ErrorsWhichAreNotIgnoredEndTheWait(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	jsErr := newCommunicationError(&requestError{State: JavascriptError}, "ExecuteScript", "", nil)
+	c := &countingCondition{after: 3, err: jsErr}
+	err := NewFluentWait(d).PollingEvery(time.Millisecond).Ignoring(NoSuchElement).Until(c)
+	if err == nil || IsWaitTimeoutError(err) || c.checks != 1 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_FluentWait_IgnoredErrorsAreRetried(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	jsErr := newCommunicationError(&requestError{State: JavascriptError}, "ExecuteScript", "", nil)
+	c := &countingCondition{after: 3, err: jsErr}
+	err := NewFluentWait(d).PollingEvery(time.Millisecond).Ignoring(JavascriptError).Until(c)
+	if err != nil || c.checks != 3 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_FluentWait_MissingAndStaleElementsAreIgnoredByDefault(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	for _, state := range []string{NoSuchElement, StaleElementReference} {
+		comErr := newCommunicationError(&requestError{State: state}, "FindElement", "", nil)
+		c := &countingCondition{after: 3, err: comErr}
+		err := NewFluentWait(d).PollingEvery(time.Millisecond).Until(c)
+		if err != nil || c.checks != 3 {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+func Test_FluentWait_TimeoutErrorContainsTheMessage(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	err := NewFluentWait(d).
+		WithTimeout(20 * time.Millisecond).
+		PollingEvery(time.Millisecond).
+		WithMessage("the basket was not updated").
+		Until(&countingCondition{after: 1000})
+	if !IsWaitTimeoutError(err) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if err.(WaitTimeoutError).Message != "the basket was not updated" ||
+		!strings.Contains(err.Error(), "FluentWait: the basket was not updated: timed out waiting for the counter") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
@@ -0,0 +1,479 @@
+package goselenium
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Types of window that can be created by NewWindow. The remote end may
+// create the other type if it does not support the type requested.
+const (
+	TabWindowType     = "tab"
+	BrowserWindowType = "window"
+)
+
+// NewWindowPollInterval is how often WaitForNewWindow checks whether a new
+// window has been opened.
+const NewWindowPollInterval = 100 * time.Millisecond
+
+// WindowRect is the position and size of a window in CSS pixels. X and Y are
+// the position of the top left of the window relative to the screen.
+type WindowRect struct {
+	X      int  `json:"x"`
+	Y      int  `json:"y"`
+	Width  uint `json:"width"`
+	Height uint `json:"height"`
+}
+
+// WindowRectOptions is the position and size to give a window with
+// SetWindowRect. Any field that is nil is left unchanged, so the window can
+// be moved without being resized (see WindowPositionOptions) or resized
+// without being moved (see WindowSizeOptions).
+type WindowRectOptions struct {
+	X      *int  `json:"x,omitempty"`
+	Y      *int  `json:"y,omitempty"`
+	Width  *uint `json:"width,omitempty"`
+	Height *uint `json:"height,omitempty"`
+}
+
+// WindowPositionOptions creates options which move a window without
+// resizing it.
+func WindowPositionOptions(x, y int) *WindowRectOptions {
+	return &WindowRectOptions{X: &x, Y: &y}
+}
+
+// WindowSizeOptions creates options which resize a window without moving it.
+func WindowSizeOptions(width, height uint) *WindowRectOptions {
+	return &WindowRectOptions{Width: &width, Height: &height}
+}
+
+// Options creates options which move and resize a window to the rect.
+func (r WindowRect) Options() *WindowRectOptions {
+	return &WindowRectOptions{X: &r.X, Y: &r.Y, Width: &r.Width, Height: &r.Height}
+}
+
+// Window is a browser window (or tab) along with its position and size at
+// the time that it was retrieved. It is returned by CurrentWindow, and its
+// methods act on the window whether or not it is still the current window.
+type Window struct {
+	Handle string
+	Rect   WindowRect
+
+	wd *seleniumWebDriver
+}
+
+// Switch switches the current browsing context to the window.
+func (w *Window) Switch() (*SwitchToWindowResponse, error) {
+	if w.wd == nil {
+		return nil, errors.New("window: window was not retrieved from a driver")
+	}
+
+	return w.wd.SwitchToWindow(w.Handle)
+}
+
+// Close switches to the window and closes it. Another window must be
+// switched to afterwards before any further commands are sent.
+func (w *Window) Close() (*CloseWindowResponse, error) {
+	_, err := w.Switch()
+	if err != nil {
+		return nil, err
+	}
+
+	return w.wd.CloseWindow()
+}
+
+// UpdateRect switches to the window, retrieves its current position and size
+// and stores them in Rect.
+func (w *Window) UpdateRect() (*WindowRectResponse, error) {
+	_, err := w.Switch()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := w.wd.WindowRect()
+	if err != nil {
+		return nil, err
+	}
+	w.Rect = resp.Rect
+
+	return resp, nil
+}
+
+// NewWindowResponse is the response returned from calling the NewWindow
+// method. Type is the type of window that was actually created.
+type NewWindowResponse struct {
+	State  string
+	Handle string
+	Type   string
+}
+
+// WindowRectResponse is the response returned from calling the WindowRect
+// method.
+type WindowRectResponse struct {
+	State string
+	Rect  WindowRect
+}
+
+// SetWindowRectResponse is the response returned from calling the
+// SetWindowRect method. Rect is the position and size of the window after it
+// has been changed, which may differ from what was requested if the window
+// could not be moved or resized exactly.
+type SetWindowRectResponse struct {
+	State string
+	Rect  WindowRect
+}
+
+// MinimizeWindowResponse is the response returned from calling the
+// MinimizeWindow method.
+type MinimizeWindowResponse struct {
+	State string
+	Rect  WindowRect
+}
+
+// FullscreenWindowResponse is the response returned from calling the
+// FullscreenWindow method.
+type FullscreenWindowResponse struct {
+	State string
+	Rect  WindowRect
+}
+
+func (s *seleniumWebDriver) NewWindow(windowType string) (*NewWindowResponse, error) {
+	if windowType != TabWindowType && windowType != BrowserWindowType {
+		return nil, errors.New("newwindow: invalid window type argument")
+	} else if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("NewWindow")
+	}
+
+	url := fmt.Sprintf("%s/session/%s/window/new", s.seleniumURL, s.sessionID)
+
+	params := map[string]string{
+		"type": windowType,
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, newMarshallingError(err, "NewWindow", params)
+	}
+
+	resp, err := s.rawValueRequest(&request{
+		url:           url,
+		method:        "POST",
+		body:          bytes.NewReader(body),
+		callingMethod: "NewWindow",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var value struct {
+		Handle string `json:"handle"`
+		Type   string `json:"type"`
+	}
+	err = json.Unmarshal(resp.Value, &value)
+	if err != nil {
+		return nil, newUnmarshallingError(err, "NewWindow", string(resp.Value))
+	}
+
+	return &NewWindowResponse{State: resp.State, Handle: value.Handle, Type: value.Type}, nil
+}
+
+func (s *seleniumWebDriver) WindowRect() (*WindowRectResponse, error) {
+	if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("WindowRect")
+	}
+
+	url := fmt.Sprintf("%s/session/%s/window/rect", s.seleniumURL, s.sessionID)
+
+	state, rect, err := s.windowRectRequest(url, "GET", nil, "WindowRect")
+	if isUnknownCommand(err) {
+		return s.legacyWindowRect()
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &WindowRectResponse{State: state, Rect: *rect}, nil
+}
+
+// legacyWindowRect retrieves the rect of the window from the size and
+// position endpoints used by drivers that predate the W3C specification. If
+// the position cannot be retrieved either, it is left as zero.
+func (s *seleniumWebDriver) legacyWindowRect() (*WindowRectResponse, error) {
+	size, err := s.WindowSize()
+	if err != nil {
+		return nil, err
+	}
+
+	rect := WindowRect{Width: size.Dimensions.Width, Height: size.Dimensions.Height}
+
+	url := fmt.Sprintf("%s/session/%s/window/position", s.seleniumURL, s.sessionID)
+	_, position, err := s.windowRectRequest(url, "GET", nil, "WindowRect")
+	if err == nil {
+		rect.X, rect.Y = position.X, position.Y
+	} else if !isUnknownCommand(err) {
+		return nil, err
+	}
+
+	return &WindowRectResponse{State: size.State, Rect: rect}, nil
+}
+
+func (s *seleniumWebDriver) SetWindowRect(opts *WindowRectOptions) (*SetWindowRectResponse, error) {
+	if opts == nil {
+		return nil, errors.New("setwindowrect: invalid options argument")
+	} else if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("SetWindowRect")
+	}
+
+	url := fmt.Sprintf("%s/session/%s/window/rect", s.seleniumURL, s.sessionID)
+
+	body, err := json.Marshal(opts)
+	if err != nil {
+		return nil, newMarshallingError(err, "SetWindowRect", opts)
+	}
+
+	state, newRect, err := s.windowRectRequest(url, "POST", body, "SetWindowRect")
+	if isUnknownCommand(err) {
+		return s.legacySetWindowRect(opts)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &SetWindowRectResponse{State: state, Rect: *newRect}, nil
+}
+
+// legacySetWindowRect moves and resizes the window using the endpoints used
+// by drivers that predate the W3C specification. Those endpoints need both
+// coordinates of the position or size, so any that were not set are taken
+// from the current rect of the window, and an endpoint is not called at all
+// if none of its fields were set.
+func (s *seleniumWebDriver) legacySetWindowRect(opts *WindowRectOptions) (*SetWindowRectResponse, error) {
+	var rect WindowRect
+	if opts.X == nil || opts.Y == nil || opts.Width == nil || opts.Height == nil {
+		current, err := s.legacyWindowRect()
+		if err != nil {
+			return nil, err
+		}
+		rect = current.Rect
+	}
+	if opts.X != nil {
+		rect.X = *opts.X
+	}
+	if opts.Y != nil {
+		rect.Y = *opts.Y
+	}
+	if opts.Width != nil {
+		rect.Width = *opts.Width
+	}
+	if opts.Height != nil {
+		rect.Height = *opts.Height
+	}
+
+	state := ""
+	if opts.Width != nil || opts.Height != nil {
+		resp, err := s.SetWindowSize(&Dimensions{Width: rect.Width, Height: rect.Height})
+		if err != nil {
+			return nil, err
+		}
+		state = resp.State
+	}
+
+	if opts.X != nil || opts.Y != nil {
+		url := fmt.Sprintf("%s/session/%s/window/position", s.seleniumURL, s.sessionID)
+
+		params := map[string]int{
+			"x": rect.X,
+			"y": rect.Y,
+		}
+		body, err := json.Marshal(params)
+		if err != nil {
+			return nil, newMarshallingError(err, "SetWindowRect", params)
+		}
+
+		resp, err := s.stateRequest(&request{
+			url:           url,
+			method:        "POST",
+			body:          bytes.NewReader(body),
+			callingMethod: "SetWindowRect",
+		})
+		if err != nil {
+			return nil, err
+		}
+		state = resp.State
+	}
+
+	return &SetWindowRectResponse{State: state, Rect: rect}, nil
+}
+
+func (s *seleniumWebDriver) MinimizeWindow() (*MinimizeWindowResponse, error) {
+	if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("MinimizeWindow")
+	}
+
+	url := fmt.Sprintf("%s/session/%s/window/minimize", s.seleniumURL, s.sessionID)
+
+	state, rect, err := s.windowRectRequest(url, "POST", nil, "MinimizeWindow")
+	if isUnknownCommand(err) {
+		// Drivers which predate the W3C specification have no equivalent.
+		return nil, newUnsupportedCommandError("MinimizeWindow", err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &MinimizeWindowResponse{State: state, Rect: *rect}, nil
+}
+
+func (s *seleniumWebDriver) FullscreenWindow() (*FullscreenWindowResponse, error) {
+	if len(s.sessionID) == 0 {
+		return nil, newSessionIDError("FullscreenWindow")
+	}
+
+	url := fmt.Sprintf("%s/session/%s/window/fullscreen", s.seleniumURL, s.sessionID)
+
+	state, rect, err := s.windowRectRequest(url, "POST", nil, "FullscreenWindow")
+	if isUnknownCommand(err) {
+		// Drivers which predate the W3C specification have no equivalent.
+		return nil, newUnsupportedCommandError("FullscreenWindow", err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &FullscreenWindowResponse{State: state, Rect: *rect}, nil
+}
+
+func (s *seleniumWebDriver) CurrentWindow() (*Window, error) {
+	handle, err := s.WindowHandle()
+	if err != nil {
+		return nil, err
+	}
+
+	rect, err := s.WindowRect()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Window{Handle: handle.Handle, Rect: rect.Rect, wd: s}, nil
+}
+
+// windowRectRequest performs a request which returns the rect of the window.
+func (s *seleniumWebDriver) windowRectRequest(url string, method string, body []byte, callingMethod string) (string, *WindowRect, error) {
+	req := &request{
+		url:           url,
+		method:        method,
+		callingMethod: callingMethod,
+	}
+	if body != nil {
+		req.body = bytes.NewReader(body)
+	}
+
+	resp, err := s.rawValueRequest(req)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var rect WindowRect
+	if len(resp.Value) > 0 && string(resp.Value) != "null" {
+		err = json.Unmarshal(resp.Value, &rect)
+		if err != nil {
+			return "", nil, newUnmarshallingError(err, callingMethod, string(resp.Value))
+		}
+	}
+
+	return resp.State, &rect, nil
+}
+
+func (s *seleniumWebDriver) WaitForNewWindow(ctx context.Context, action func() error, match Condition) (string, error) {
+	if ctx == nil {
+		return "", errors.New("waitfornewwindow: invalid ctx argument")
+	} else if action == nil {
+		return "", errors.New("waitfornewwindow: invalid action argument")
+	} else if match != nil && !validConditions([]Condition{match}) {
+		return "", errors.New("waitfornewwindow: invalid match argument")
+	} else if len(s.sessionID) == 0 {
+		return "", newSessionIDError("WaitForNewWindow")
+	}
+
+	current, err := s.WindowHandle()
+	if err != nil {
+		return "", err
+	}
+	existing, err := s.WindowHandles()
+	if err != nil {
+		return "", err
+	}
+	known := make(map[string]bool, len(existing.Handles))
+	for _, h := range existing.Handles {
+		known[h] = true
+	}
+
+	err = action()
+	if err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	for attempts := 1; ; attempts++ {
+		handle, err := s.findNewWindow(known, current.Handle, match)
+		if err != nil || handle != "" {
+			return handle, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", newWaitTimeoutError("WaitForNewWindow", "a new window", attempts, time.Since(start), nil, nil, ctx.Err())
+		case <-time.After(NewWindowPollInterval):
+		}
+	}
+}
+
+// findNewWindow switches to each window that is not known and returns the
+// handle of the first that satisfies match (or of the first new window if
+// match is nil). If none of them do, the window which was current is switched
+// back to.
+func (s *seleniumWebDriver) findNewWindow(known map[string]bool, current string, match Condition) (string, error) {
+	resp, err := s.WindowHandles()
+	if err != nil {
+		return "", err
+	}
+
+	switched := false
+	for _, h := range resp.Handles {
+		if known[h] {
+			continue
+		}
+
+		_, err = s.SwitchToWindow(h)
+		if err != nil {
+			// The window may have closed itself since the handles were
+			// retrieved, in which case it cannot be the one being waited for.
+			comErr, ok := err.(CommunicationError)
+			if ok && comErr.Response != nil && comErr.Response.State == NoSuchWindow {
+				continue
+			}
+			return "", err
+		}
+		switched = true
+
+		if match == nil {
+			return h, nil
+		}
+		// An error (such as the page not having loaded yet) means that the
+		// window does not match yet rather than the wait failing.
+		ok, err := match.Check(s)
+		if _, invalid := err.(invalidConditionError); invalid {
+			return "", err
+		} else if ok && err == nil {
+			return h, nil
+		}
+	}
+
+	if switched {
+		_, err = s.SwitchToWindow(current)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,533 @@
+package goselenium
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+// windowsAPIService simulates a browser with several windows. Each time that
+// the handles are retrieved, the next of the pending windows is opened.
+type windowsAPIService struct {
+	handles  []string
+	pending  []string
+	titles   map[string]string
+	current  string
+	switches []string
+}
+
+func (w *windowsAPIService) performRequest(url string, method string, body io.Reader) ([]byte, error) {
+	switch {
+	case strings.HasSuffix(url, "/window/handles"):
+		if len(w.pending) > 0 {
+			w.handles = append(w.handles, w.pending[0])
+			w.pending = w.pending[1:]
+		}
+		b, _ := json.Marshal(w.handles)
+		return []byte(`{"state": "success", "value": ` + string(b) + `}`), nil
+	case strings.HasSuffix(url, "/window") && method == "GET":
+		return []byte(`{"state": "success", "value": "` + w.current + `"}`), nil
+	case strings.HasSuffix(url, "/window"):
+		b, _ := ioutil.ReadAll(body)
+		var req struct {
+			Handle string `json:"handle"`
+		}
+		json.Unmarshal(b, &req)
+		w.current = req.Handle
+		w.switches = append(w.switches, req.Handle)
+		return []byte(`{"state": "success"}`), nil
+	case strings.HasSuffix(url, "/title"):
+		return []byte(`{"state": "success", "value": "` + w.titles[w.current] + `"}`), nil
+	}
+
+	return nil, errors.New("no route for " + url)
+}
+
+func setUpWindows() (*seleniumWebDriver, *windowsAPIService) {
+	api := &windowsAPIService{
+		handles: []string{"main"},
+		titles: map[string]string{
+			"main":    "Dashboard",
+			"advert":  "Special offer",
+			"invoice": "Invoice",
+		},
+		current: "main",
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	return d, api
+}
+
+/*
+NewWindow tests
+*/
+func Test_NewWindow_InvalidWindowTypeResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	d.sessionID = "12345"
+
+	_, err := d.NewWindow("popup")
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_NewWindow_InvalidSessionIdResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	_, err := d.NewWindow(TabWindowType)
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_NewWindow_CommunicationErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: errors.New("An error :< "),
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.NewWindow(TabWindowType)
+	if err == nil || !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_NewWindow_ResultIsReturnedSuccessfully(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": {"handle": "CDwindow-2", "type": "tab"}
+		}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.NewWindow(TabWindowType)
+	if err != nil || resp.Handle != "CDwindow-2" || resp.Type != TabWindowType {
+		t.Errorf(correctResponseErrorText)
+	}
+	if !strings.HasSuffix(api.lastURL, "/window/new") || api.lastBody != `{"type":"tab"}` {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+WindowRect tests
+*/
+func Test_WindowRect_InvalidSessionIdResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	_, err := d.WindowRect()
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_WindowRect_ResultIsReturnedSuccessfully(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": {"x": 10, "y": -20, "width": 1024, "height": 768}
+		}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.WindowRect()
+	if err != nil || resp.Rect != (WindowRect{X: 10, Y: -20, Width: 1024, Height: 768}) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WindowRect_FallsBackToLegacyEndpoints(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/window/size":     `{"state": "success", "value": {"width": 800, "height": 600}}`,
+			"/window/position": `{"state": "success", "value": {"x": 5, "y": 15}}`,
+		},
+		errors: map[string]error{
+			"/window/rect": &requestError{State: UnknownCommand},
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.WindowRect()
+	if err != nil || resp.Rect != (WindowRect{X: 5, Y: 15, Width: 800, Height: 600}) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WindowRect_LegacyFallbackToleratesMissingPosition(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/window/size": `{"state": "success", "value": {"width": 800, "height": 600}}`,
+		},
+		errors: map[string]error{
+			"/window/rect":     &requestError{State: UnknownCommand},
+			"/window/position": &requestError{State: UnknownMethod},
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.WindowRect()
+	if err != nil || resp.Rect != (WindowRect{Width: 800, Height: 600}) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+SetWindowRect tests
+*/
+func Test_SetWindowRect_InvalidRectResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	d.sessionID = "12345"
+
+	_, err := d.SetWindowRect(nil)
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_SetWindowRect_InvalidSessionIdResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	_, err := d.SetWindowRect(WindowSizeOptions(800, 600))
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_SetWindowRect_ResultIsReturnedSuccessfully(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": {"x": 0, "y": 0, "width": 1280, "height": 720}
+		}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.SetWindowRect(WindowSizeOptions(1280, 720))
+	if err != nil || resp.Rect.Width != 1280 || resp.Rect.Height != 720 {
+		t.Errorf(correctResponseErrorText)
+	}
+	if api.lastBody != `{"width":1280,"height":720}` {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_SetWindowRect_FallsBackToLegacyEndpoints(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/window/size":     `{"state": "success"}`,
+			"/window/position": `{"state": "success"}`,
+		},
+		errors: map[string]error{
+			"/window/rect": &requestError{State: UnknownCommand},
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	rect := WindowRect{X: 50, Y: 60, Width: 800, Height: 600}
+	resp, err := d.SetWindowRect(rect.Options())
+	if err != nil || resp.Rect != rect {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	if len(api.requests) != 3 || !strings.HasSuffix(api.requests[2], "/window/position") ||
+		api.bodies[2] != `{"x":50,"y":60}` {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_SetWindowRect_ZeroPositionIsSent(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{"state": "success", "value": {"x": 0, "y": 0, "width": 800, "height": 600}}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.SetWindowRect(WindowPositionOptions(0, 0))
+	if err != nil || api.lastBody != `{"x":0,"y":0}` {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_SetWindowRect_LegacyFallbackOnlyResizes(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/window/size":     `{"state": "success", "value": {"width": 800, "height": 600}}`,
+			"/window/position": `{"state": "success", "value": {"x": 5, "y": 15}}`,
+		},
+		errors: map[string]error{
+			"/window/rect": &requestError{State: UnknownCommand},
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	width := uint(1280)
+	resp, err := d.SetWindowRect(&WindowRectOptions{Width: &width})
+	if err != nil || resp.Rect != (WindowRect{X: 5, Y: 15, Width: 1280, Height: 600}) {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	for i, r := range api.requests {
+		if strings.HasPrefix(r, "POST") && strings.HasSuffix(r, "/window/position") {
+			t.Errorf("expected the window not to be moved")
+		}
+		if strings.HasPrefix(r, "POST") && strings.HasSuffix(r, "/window/size") &&
+			api.bodies[i] != `{"height":600,"width":1280}` {
+			t.Errorf(correctResponseErrorText)
+		}
+	}
+}
+
+/*
+MinimizeWindow and FullscreenWindow tests
+*/
+func Test_MinimizeWindow_InvalidSessionIdResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+
+	_, err := d.MinimizeWindow()
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_MinimizeWindow_ResultIsReturnedSuccessfully(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": {"x": 0, "y": 0, "width": 1024, "height": 768}
+		}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.MinimizeWindow()
+	if err != nil || resp.Rect.Width != 1024 || !strings.HasSuffix(api.lastURL, "/window/minimize") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_FullscreenWindow_CommunicationErrorIsReturnedCorrectly(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn:  "",
+		errorToReturn: errors.New("An error :< "),
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.FullscreenWindow()
+	if err == nil || !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_FullscreenWindow_ResultIsReturnedSuccessfully(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": {"x": 0, "y": 0, "width": 1920, "height": 1080}
+		}`,
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := d.FullscreenWindow()
+	if err != nil || resp.Rect.Height != 1080 || !strings.HasSuffix(api.lastURL, "/window/fullscreen") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_MinimizeAndFullscreenWindow_UnknownCommandIsUnsupported(t *testing.T) {
+	api := &testableAPIService{
+		errorToReturn: &requestError{State: UnknownCommand},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := d.MinimizeWindow()
+	if !IsUnsupportedCommandError(err) || !strings.Contains(err.Error(), "MinimizeWindow: not supported") {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	_, err = d.FullscreenWindow()
+	var comErr CommunicationError
+	if !IsUnsupportedCommandError(err) || !errors.As(err, &comErr) || comErr.Response.State != UnknownCommand {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+/*
+CurrentWindow tests
+*/
+func Test_CurrentWindow_ResultIsReturnedSuccessfully(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/window":      `{"state": "success", "value": "CDwindow-1"}`,
+			"/window/rect": `{"state": "success", "value": {"x": 1, "y": 2, "width": 3, "height": 4}}`,
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	w, err := d.CurrentWindow()
+	if err != nil || w.Handle != "CDwindow-1" || w.Rect != (WindowRect{X: 1, Y: 2, Width: 3, Height: 4}) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Window_WindowIsSwitchedToBeforeEachCommand(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/window":      `{"state": "success", "value": "CDwindow-1"}`,
+			"/window/rect": `{"state": "success", "value": {"x": 1, "y": 2, "width": 3, "height": 4}}`,
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	w, err := d.CurrentWindow()
+	if err != nil {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	api.routes["/window/rect"] = `{"state": "success", "value": {"x": 5, "y": 6, "width": 7, "height": 8}}`
+	api.requests, api.bodies = nil, nil
+	resp, err := w.UpdateRect()
+	if err != nil || resp.Rect != w.Rect || w.Rect.X != 5 {
+		t.Errorf(correctResponseErrorText)
+	}
+	if len(api.requests) != 2 || !strings.HasPrefix(api.requests[0], "POST ") ||
+		!strings.Contains(api.bodies[0], `"handle":"CDwindow-1"`) {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	api.routes["/window"] = `{"state": "success", "value": ["CDwindow-2"]}`
+	api.requests = nil
+	_, err = w.Close()
+	if err != nil || len(api.requests) != 2 || !strings.HasPrefix(api.requests[1], "DELETE ") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Window_WindowsNotRetrievedFromADriverResultInError(t *testing.T) {
+	w := &Window{Handle: "CDwindow-1"}
+
+	if _, err := w.Switch(); err == nil {
+		t.Errorf(argumentErrorText)
+	}
+	if _, err := w.Close(); err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+/*
+WaitForNewWindow tests
+*/
+func Test_WaitForNewWindow_InvalidArgumentsResultInError(t *testing.T) {
+	d, _ := setUpWindows()
+
+	_, err := d.WaitForNewWindow(context.Background(), nil, nil)
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+
+	called := false
+	_, err = d.WaitForNewWindow(context.Background(), func() error {
+		called = true
+		return nil
+	}, UntilElementCount(nil, 1))
+	if err == nil || called {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_WaitForNewWindow_AcceptsUntilFunctions(t *testing.T) {
+	d, api := setUpWindows()
+
+	handle, err := d.WaitForNewWindow(context.Background(), func() error {
+		api.pending = []string{"advert", "invoice"}
+		return nil
+	}, Until(func(w WebDriver) bool {
+		resp, err := w.WindowHandle()
+		return err == nil && resp.Handle == "invoice"
+	}))
+	if err != nil || handle != "invoice" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitForNewWindow_ActionErrorIsReturned(t *testing.T) {
+	d, _ := setUpWindows()
+
+	expected := errors.New("click failed")
+	_, err := d.WaitForNewWindow(context.Background(), func() error {
+		return expected
+	}, nil)
+	if err != expected {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitForNewWindow_SwitchesToTheFirstNewWindow(t *testing.T) {
+	d, api := setUpWindows()
+
+	called := false
+	handle, err := d.WaitForNewWindow(context.Background(), func() error {
+		called = true
+		api.pending = []string{"invoice"}
+		return nil
+	}, nil)
+	if err != nil || !called || handle != "invoice" || api.current != "invoice" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitForNewWindow_WaitsForAMatchingWindow(t *testing.T) {
+	d, api := setUpWindows()
+
+	handle, err := d.WaitForNewWindow(context.Background(), func() error {
+		api.pending = []string{"advert", "invoice"}
+		return nil
+	}, UntilTitleIs("Invoice"))
+	if err != nil || handle != "invoice" || api.current != "invoice" {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	// The advert is switched to, rejected and then switched away from.
+	if strings.Join(api.switches, ",") != "advert,main,advert,invoice" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_WaitForNewWindow_RestoresTheWindowWhenTheContextIsDone(t *testing.T) {
+	d, api := setUpWindows()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := d.WaitForNewWindow(ctx, func() error {
+		api.pending = []string{"advert"}
+		return nil
+	}, UntilTitleIs("Invoice"))
+	if !IsWaitTimeoutError(err) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf(correctResponseErrorText)
+	}
+	if api.current != "main" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
@@ -3,7 +3,9 @@ package goselenium
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 )
 
 func newSeleniumElement(i string, w *seleniumWebDriver) *seleniumElement {
@@ -83,15 +85,24 @@ type ElementSendKeysResponse struct {
 }
 
 type seleniumElement struct {
-	id string
-	wd *seleniumWebDriver
+	mu      sync.RWMutex
+	id      string
+	wd      *seleniumWebDriver
+	locator *elementLocator
 }
 
 func (s *seleniumElement) ID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	return s.id
 }
 
 func (s *seleniumElement) Selected() (*ElementSelectedResponse, error) {
+	return withStaleRecovery(s, "Selected", s.selected)
+}
+
+func (s *seleniumElement) selected() (*ElementSelectedResponse, error) {
 	var el ElementSelectedResponse
 	var err error
 
@@ -111,6 +122,12 @@ func (s *seleniumElement) Selected() (*ElementSelectedResponse, error) {
 }
 
 func (s *seleniumElement) Attribute(att string) (*ElementAttributeResponse, error) {
+	return withStaleRecovery(s, "Attribute", func() (*ElementAttributeResponse, error) {
+		return s.attribute(att)
+	})
+}
+
+func (s *seleniumElement) attribute(att string) (*ElementAttributeResponse, error) {
 	var err error
 
 	url := fmt.Sprintf("%s/session/%s/element/%s/attribute/%s", s.wd.seleniumURL, s.wd.sessionID, s.ID(), att)
@@ -129,6 +146,12 @@ func (s *seleniumElement) Attribute(att string) (*ElementAttributeResponse, erro
 }
 
 func (s *seleniumElement) CSSValue(prop string) (*ElementCSSValueResponse, error) {
+	return withStaleRecovery(s, "CSSValue", func() (*ElementCSSValueResponse, error) {
+		return s.cssValue(prop)
+	})
+}
+
+func (s *seleniumElement) cssValue(prop string) (*ElementCSSValueResponse, error) {
 	var err error
 
 	url := fmt.Sprintf("%s/session/%s/element/%s/css/%s", s.wd.seleniumURL, s.wd.sessionID, s.ID(), prop)
@@ -147,6 +170,10 @@ func (s *seleniumElement) CSSValue(prop string) (*ElementCSSValueResponse, error
 }
 
 func (s *seleniumElement) Text() (*ElementTextResponse, error) {
+	return withStaleRecovery(s, "Text", s.text)
+}
+
+func (s *seleniumElement) text() (*ElementTextResponse, error) {
 	var err error
 
 	url := fmt.Sprintf("%s/session/%s/element/%s/text", s.wd.seleniumURL, s.wd.sessionID, s.ID())
@@ -165,6 +192,10 @@ func (s *seleniumElement) Text() (*ElementTextResponse, error) {
 }
 
 func (s *seleniumElement) TagName() (*ElementTagNameResponse, error) {
+	return withStaleRecovery(s, "TagName", s.tagName)
+}
+
+func (s *seleniumElement) tagName() (*ElementTagNameResponse, error) {
 	var err error
 
 	url := fmt.Sprintf("%s/session/%s/element/%s/name", s.wd.seleniumURL, s.wd.sessionID, s.ID())
@@ -183,6 +214,10 @@ func (s *seleniumElement) TagName() (*ElementTagNameResponse, error) {
 }
 
 func (s *seleniumElement) Rectangle() (*ElementRectangleResponse, error) {
+	return withStaleRecovery(s, "Rectangle", s.rectangle)
+}
+
+func (s *seleniumElement) rectangle() (*ElementRectangleResponse, error) {
 	var response ElementRectangleResponse
 	var err error
 
@@ -202,6 +237,10 @@ func (s *seleniumElement) Rectangle() (*ElementRectangleResponse, error) {
 }
 
 func (s *seleniumElement) Enabled() (*ElementEnabledResponse, error) {
+	return withStaleRecovery(s, "Enabled", s.enabled)
+}
+
+func (s *seleniumElement) enabled() (*ElementEnabledResponse, error) {
 	var response ElementEnabledResponse
 	var err error
 
@@ -221,6 +260,18 @@ func (s *seleniumElement) Enabled() (*ElementEnabledResponse, error) {
 }
 
 func (s *seleniumElement) Click() (*ElementClickResponse, error) {
+	return withStaleRecovery(s, "Click", s.click)
+}
+
+func (s *seleniumElement) click() (*ElementClickResponse, error) {
+	if opts := s.wd.robustClickOptions(); opts != nil {
+		return s.robustClick(opts)
+	}
+
+	return s.nativeClick()
+}
+
+func (s *seleniumElement) nativeClick() (*ElementClickResponse, error) {
 	var err error
 
 	url := fmt.Sprintf("%s/session/%s/element/%s/click", s.wd.seleniumURL, s.wd.sessionID, s.ID())
@@ -239,6 +290,10 @@ func (s *seleniumElement) Click() (*ElementClickResponse, error) {
 }
 
 func (s *seleniumElement) Clear() (*ElementClearResponse, error) {
+	return withStaleRecovery(s, "Clear", s.clear)
+}
+
+func (s *seleniumElement) clear() (*ElementClearResponse, error) {
 	var err error
 
 	url := fmt.Sprintf("%s/session/%s/element/%s/clear", s.wd.seleniumURL, s.wd.sessionID, s.ID())
@@ -257,13 +312,19 @@ func (s *seleniumElement) Clear() (*ElementClearResponse, error) {
 }
 
 func (s *seleniumElement) SendKeys(keys string) (*ElementSendKeysResponse, error) {
+	return withStaleRecovery(s, "SendKeys", func() (*ElementSendKeysResponse, error) {
+		return s.sendKeys(keys)
+	})
+}
+
+func (s *seleniumElement) sendKeys(keys string) (*ElementSendKeysResponse, error) {
 	var err error
 
 	url := fmt.Sprintf("%s/session/%s/element/%s/value", s.wd.seleniumURL, s.wd.sessionID, s.ID())
 
-	keyChars := make([]string, len(keys))
-	for i, k := range keys {
-		keyChars[i] = string(k)
+	keyChars := make([]string, 0, len(keys))
+	for _, k := range keys {
+		keyChars = append(keyChars, string(k))
 	}
 	dict := map[string][]string{
 		"value": keyChars,
@@ -286,3 +347,40 @@ func (s *seleniumElement) SendKeys(keys string) (*ElementSendKeysResponse, error
 
 	return &ElementSendKeysResponse{State: resp.State}, nil
 }
+
+func (s *seleniumElement) FindElement(by By) (Element, error) {
+	return withStaleRecovery(s, "FindElement", func() (Element, error) {
+		return s.findElement(by)
+	})
+}
+
+func (s *seleniumElement) findElement(by By) (Element, error) {
+	if by == nil || by.Type() == "index" {
+		return nil, errors.New("findelement: invalid by argument")
+	}
+
+	elements, err := s.wd.findElements(by, s, "FindElement")
+	if err != nil {
+		return nil, err
+	}
+	if len(elements) == 0 {
+		url := fmt.Sprintf("%s/session/%s/element/%s/elements", s.wd.seleniumURL, s.wd.sessionID, s.ID())
+		return nil, newNoSuchElementError("FindElement", url, by)
+	}
+
+	return elements[0], nil
+}
+
+func (s *seleniumElement) FindElements(by By) ([]Element, error) {
+	return withStaleRecovery(s, "FindElements", func() ([]Element, error) {
+		return s.findElements(by)
+	})
+}
+
+func (s *seleniumElement) findElements(by By) ([]Element, error) {
+	if by == nil || by.Type() == "index" {
+		return nil, errors.New("findelements: invalid by argument")
+	}
+
+	return s.wd.findElements(by, s, "FindElements")
+}
@@ -0,0 +1,233 @@
+package goselenium
+
+import (
+	"strings"
+	"time"
+)
+
+// Default values of RobustClickOptions which are used for any option that is
+// not set.
+const (
+	DefaultRobustClickTimeout  = 2 * time.Second
+	DefaultRobustClickInterval = 100 * time.Millisecond
+	DefaultRobustClickAttempts = 3
+)
+
+// RobustClickOptions configures how elements are clicked once EnableRobustClick
+// has been called.
+type RobustClickOptions struct {
+	// Timeout is how long to wait, on each attempt, for the element to stop
+	// moving and to no longer be covered by another element.
+	Timeout time.Duration
+
+	// Interval is how long to wait between checking the element and between
+	// attempts.
+	Interval time.Duration
+
+	// Attempts is the number of times to try clicking the element before
+	// returning an ElementClickInterceptedError.
+	Attempts int
+}
+
+// ElementScrollIntoViewResponse is the response returned from calling the
+// ScrollIntoView method.
+type ElementScrollIntoViewResponse struct {
+	State string
+}
+
+// scrollIntoViewScript scrolls arguments[0] into the centre of the viewport.
+const scrollIntoViewScript = `arguments[0].scrollIntoView({block: 'center', inline: 'center', behavior: 'instant'});`
+
+// clickableScript optionally scrolls arguments[0] into view (if arguments[1]
+// is true) and returns its position along with a description of the element
+// at its centre if that is not the element itself or one of its descendants.
+const clickableScript = `
+var el = arguments[0];
+if (arguments[1]) {
+	el.scrollIntoView({block: 'center', inline: 'center', behavior: 'instant'});
+}
+var r = el.getBoundingClientRect();
+var hit = el.ownerDocument.elementFromPoint(r.left + r.width / 2, r.top + r.height / 2);
+var obscurer = null;
+if (hit && hit !== el && !el.contains(hit)) {
+	obscurer = '<' + hit.tagName.toLowerCase();
+	if (hit.id) {
+		obscurer += ' id="' + hit.id + '"';
+	}
+	if (typeof hit.className === 'string' && hit.className) {
+		obscurer += ' class="' + hit.className + '"';
+	}
+	obscurer += '>';
+}
+return {rect: [r.left, r.top, r.width, r.height], obscurer: obscurer};
+`
+
+// interceptedPrefix precedes the description of the element that would have
+// received a click in the message of an 'element click intercepted' error.
+const interceptedPrefix = "Other element would receive the click: "
+
+func (s *seleniumWebDriver) EnableRobustClick(opts *RobustClickOptions) {
+	o := RobustClickOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultRobustClickTimeout
+	}
+	if o.Interval <= 0 {
+		o.Interval = DefaultRobustClickInterval
+	}
+	if o.Attempts <= 0 {
+		o.Attempts = DefaultRobustClickAttempts
+	}
+
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+
+	s.robustClick = &o
+}
+
+func (s *seleniumWebDriver) DisableRobustClick() {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+
+	s.robustClick = nil
+}
+
+func (s *seleniumWebDriver) robustClickOptions() *RobustClickOptions {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+
+	return s.robustClick
+}
+
+func (s *seleniumElement) ScrollIntoView() (*ElementScrollIntoViewResponse, error) {
+	return withStaleRecovery(s, "ScrollIntoView", s.scrollIntoView)
+}
+
+func (s *seleniumElement) scrollIntoView() (*ElementScrollIntoViewResponse, error) {
+	if len(s.wd.sessionID) == 0 {
+		return nil, newSessionIDError("ScrollIntoView")
+	}
+
+	resp, err := s.wd.scriptRequest(scrollIntoViewScript, []interface{}{s}, false, "ScrollIntoView")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ElementScrollIntoViewResponse{State: resp.State}, nil
+}
+
+func (s *seleniumElement) robustClick(opts *RobustClickOptions) (*ElementClickResponse, error) {
+	if len(s.wd.sessionID) == 0 {
+		return nil, newSessionIDError("Click")
+	}
+
+	var obscurer string
+	for attempt := 1; attempt <= opts.Attempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(opts.Interval)
+		}
+
+		var err error
+		obscurer, err = s.waitUntilClickable(opts)
+		if err != nil {
+			return nil, err
+		}
+		if obscurer != "" {
+			continue
+		}
+
+		resp, err := s.nativeClick()
+		if err == nil {
+			return resp, nil
+		}
+		if !isClickInterceptedError(err) {
+			return nil, err
+		}
+		obscurer = interceptingElement(err)
+	}
+
+	return nil, newElementClickInterceptedError("Click", s.ID(), obscurer, opts.Attempts)
+}
+
+// waitUntilClickable scrolls the element into view and waits until it is in
+// the same position twice in a row and is not covered by another element.
+// If the element is still covered when the timeout is reached, a description
+// of the covering element is returned.
+func (s *seleniumElement) waitUntilClickable(opts *RobustClickOptions) (string, error) {
+	var state struct {
+		Rect     []float64 `json:"rect"`
+		Obscurer *string   `json:"obscurer"`
+	}
+
+	var previous []float64
+	deadline := time.Now().Add(opts.Timeout)
+	for i := 0; ; i++ {
+		resp, err := s.wd.scriptRequest(clickableScript, []interface{}{s, i == 0}, false, "Click")
+		if err != nil {
+			return "", err
+		}
+
+		state.Obscurer = nil
+		err = resp.Decode(&state)
+		if err != nil {
+			return "", newUnmarshallingError(err, "Click", string(resp.Value))
+		}
+
+		stable := previous != nil && equalRects(previous, state.Rect)
+		if stable && state.Obscurer == nil {
+			return "", nil
+		}
+		if time.Now().After(deadline) {
+			if state.Obscurer != nil {
+				return *state.Obscurer, nil
+			}
+
+			// The element is still moving (i.e. it is animated) but is not
+			// covered, so there is nothing to gain by waiting any longer.
+			return "", nil
+		}
+
+		previous = state.Rect
+		time.Sleep(opts.Interval)
+	}
+}
+
+func equalRects(a []float64, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isClickInterceptedError checks whether an error was returned because
+// another element would have received a click.
+func isClickInterceptedError(err error) bool {
+	comErr, ok := err.(CommunicationError)
+	return ok && comErr.Response != nil && comErr.Response.State == ElementClickIntercepted
+}
+
+// interceptingElement reads the element that would have received a click from
+// the message of an 'element click intercepted' error.
+func interceptingElement(err error) string {
+	message := err.(CommunicationError).Response.Message
+
+	i := strings.Index(message, interceptedPrefix)
+	if i == -1 {
+		return ""
+	}
+
+	obscurer := message[i+len(interceptedPrefix):]
+	if j := strings.IndexByte(obscurer, '\n'); j != -1 {
+		obscurer = obscurer[:j]
+	}
+
+	return strings.TrimSpace(obscurer)
+}
@@ -0,0 +1,163 @@
+package goselenium
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+var fastRobustClick = &RobustClickOptions{
+	Timeout:  5 * time.Millisecond,
+	Interval: time.Millisecond,
+	Attempts: 2,
+}
+
+func countRequests(api *routedAPIService, suffix string) int {
+	var count int
+	for _, r := range api.requests {
+		if strings.HasSuffix(r, suffix) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func Test_ElementClick_RobustClickIsNotUsedByDefault(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/click": `{"state": "success"}`,
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := newSeleniumElement("0", d).Click()
+	if err != nil || len(api.requests) != 1 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ElementClick_RobustClickWaitsUntilElementIsStable(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/execute": `{"state": "success", "value": {"rect": [0, 0, 10, 10], "obscurer": null}}`,
+			"/click":   `{"state": "success"}`,
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+	d.EnableRobustClick(fastRobustClick)
+
+	resp, err := newSeleniumElement("abc", d).Click()
+	if err != nil || resp.State != "success" {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if countRequests(api, "/execute") != 2 || countRequests(api, "/click") != 1 {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	var body struct {
+		Args []interface{} `json:"args"`
+	}
+	json.Unmarshal([]byte(api.bodies[0]), &body)
+	if body.Args[0].(map[string]interface{})[webElementIdentifier] != "abc" || body.Args[1] != true {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ElementClick_RobustClickReportsObscuringElement(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/execute": `{"state": "success", "value": {"rect": [0, 0, 10, 10], "obscurer": "<div class=\"overlay\">"}}`,
+			"/click":   `{"state": "success"}`,
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+	d.EnableRobustClick(fastRobustClick)
+
+	_, err := newSeleniumElement("abc", d).Click()
+	if !IsElementClickInterceptedError(err) {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	intercepted := err.(ElementClickInterceptedError)
+	if intercepted.Obscurer != `<div class="overlay">` || intercepted.Attempts != 2 || intercepted.ElementID != "abc" {
+		t.Errorf(correctResponseErrorText)
+	}
+	if countRequests(api, "/click") != 0 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ElementClick_RobustClickRetriesInterceptedClicks(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/execute": `{"state": "success", "value": {"rect": [0, 0, 10, 10], "obscurer": null}}`,
+		},
+		errors: map[string]error{
+			"/click": &requestError{
+				State: ElementClickIntercepted,
+				Value: requestErrorValue{
+					Message: "element click intercepted: Element <button> is not clickable at point (5, 5). " +
+						"Other element would receive the click: <header class=\"sticky\">\n  (Session info: chrome=120.0)",
+				},
+			},
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+	d.EnableRobustClick(fastRobustClick)
+
+	_, err := newSeleniumElement("abc", d).Click()
+	if !IsElementClickInterceptedError(err) || err.(ElementClickInterceptedError).Obscurer != `<header class="sticky">` {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if countRequests(api, "/click") != 2 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ElementClick_DisablingRobustClickRestoresDefault(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/click": `{"state": "success"}`,
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+	d.EnableRobustClick(nil)
+	d.DisableRobustClick()
+
+	_, err := newSeleniumElement("0", d).Click()
+	if err != nil || countRequests(api, "/execute") != 0 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ElementScrollIntoView_InvalidSessionIdResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &routedAPIService{})
+
+	_, err := newSeleniumElement("0", d).ScrollIntoView()
+	if err == nil || !IsSessionIDError(err) {
+		t.Errorf(sessionIDErrorText)
+	}
+}
+
+func Test_ElementScrollIntoView_ScriptIsExecutedWithElement(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/execute": `{"state": "success", "value": null}`,
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := newSeleniumElement("abc", d).ScrollIntoView()
+	if err != nil || resp.State != "success" || !strings.Contains(api.bodies[0], `"abc"`) ||
+		!strings.Contains(api.bodies[0], "scrollIntoView") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
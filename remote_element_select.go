@@ -0,0 +1,243 @@
+package goselenium
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Select is a helper for interacting with <select> elements. It is created by
+// calling NewSelect with the element to wrap.
+type Select struct {
+	el       Element
+	multiple bool
+}
+
+// NewSelect wraps an element in a Select. If the element is not a <select>,
+// an UnexpectedTagNameError is returned.
+func NewSelect(el Element) (*Select, error) {
+	if el == nil {
+		return nil, errors.New("newselect: invalid element argument")
+	}
+
+	tag, err := el.TagName()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(tag.Tag, "select") {
+		return nil, newUnexpectedTagNameError("NewSelect", "select", tag.Tag)
+	}
+
+	multiple, err := el.Attribute("multiple")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Select{
+		el:       el,
+		multiple: multiple.Value != "" && multiple.Value != "false",
+	}, nil
+}
+
+// Element returns the <select> element that is wrapped.
+func (s *Select) Element() Element {
+	return s.el
+}
+
+// IsMultiple returns whether more than one option can be selected at a time.
+func (s *Select) IsMultiple() bool {
+	return s.multiple
+}
+
+// Options returns all of the options belonging to the select.
+func (s *Select) Options() ([]Element, error) {
+	return s.el.FindElements(ByTagName("option"))
+}
+
+// SelectedOptions returns all of the options that are currently selected.
+func (s *Select) SelectedOptions() ([]Element, error) {
+	options, err := s.Options()
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []Element
+	for _, o := range options {
+		resp, err := o.Selected()
+		if err != nil {
+			return nil, err
+		}
+		if resp.Selected {
+			selected = append(selected, o)
+		}
+	}
+
+	return selected, nil
+}
+
+// FirstSelectedOption returns the first option that is currently selected. For
+// a single select, this is the selected option.
+func (s *Select) FirstSelectedOption() (Element, error) {
+	selected, err := s.SelectedOptions()
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		return nil, s.noSuchOptionError("FirstSelectedOption", "selected", true)
+	}
+
+	return selected[0], nil
+}
+
+// SelectByVisibleText selects all options whose text (with leading and
+// trailing whitespace removed) matches text. For a single select, only the
+// first matching option is selected.
+func (s *Select) SelectByVisibleText(text string) error {
+	return s.setBy("SelectByVisibleText", "visible text", text, true, s.textMatches(text))
+}
+
+// SelectByValue selects all options whose value attribute matches value. For
+// a single select, only the first matching option is selected.
+func (s *Select) SelectByValue(value string) error {
+	return s.setBy("SelectByValue", "value", value, true, s.valueMatches(value))
+}
+
+// SelectByIndex selects the option at the index passed in.
+func (s *Select) SelectByIndex(index int) error {
+	return s.setBy("SelectByIndex", "index", index, true, s.indexMatches(index))
+}
+
+// DeselectByVisibleText deselects all options whose text matches text. This
+// is only supported for selects which allow multiple options to be selected.
+func (s *Select) DeselectByVisibleText(text string) error {
+	return s.setBy("DeselectByVisibleText", "visible text", text, false, s.textMatches(text))
+}
+
+// DeselectByValue deselects all options whose value attribute matches value.
+// This is only supported for selects which allow multiple options to be
+// selected.
+func (s *Select) DeselectByValue(value string) error {
+	return s.setBy("DeselectByValue", "value", value, false, s.valueMatches(value))
+}
+
+// DeselectByIndex deselects the option at the index passed in. This is only
+// supported for selects which allow multiple options to be selected.
+func (s *Select) DeselectByIndex(index int) error {
+	return s.setBy("DeselectByIndex", "index", index, false, s.indexMatches(index))
+}
+
+// DeselectAll deselects every option. This is only supported for selects
+// which allow multiple options to be selected.
+func (s *Select) DeselectAll() error {
+	if !s.multiple {
+		return errors.New("deselectall: only options of a multiple select can be deselected")
+	}
+
+	selected, err := s.SelectedOptions()
+	if err != nil {
+		return err
+	}
+	for _, o := range selected {
+		_, err := o.Click()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type optionMatcher func(index int, option Element) (bool, error)
+
+func (s *Select) textMatches(text string) optionMatcher {
+	return func(index int, option Element) (bool, error) {
+		resp, err := option.Text()
+		if err != nil {
+			return false, err
+		}
+
+		return strings.TrimSpace(resp.Text) == strings.TrimSpace(text), nil
+	}
+}
+
+func (s *Select) valueMatches(value string) optionMatcher {
+	return func(index int, option Element) (bool, error) {
+		resp, err := option.Attribute("value")
+		if err != nil {
+			return false, err
+		}
+
+		return resp.Value == value, nil
+	}
+}
+
+func (s *Select) indexMatches(i int) optionMatcher {
+	return func(index int, option Element) (bool, error) {
+		return index == i, nil
+	}
+}
+
+// setBy selects (or deselects) every option that the matcher accepts.
+func (s *Select) setBy(method string, criteria string, value interface{}, selected bool, matches optionMatcher) error {
+	if !selected && !s.multiple {
+		return fmt.Errorf("%s: only options of a multiple select can be deselected", strings.ToLower(method))
+	}
+
+	options, err := s.Options()
+	if err != nil {
+		return err
+	}
+
+	var matched bool
+	for i, o := range options {
+		ok, err := matches(i, o)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		matched = true
+		err = s.setSelected(method, o, selected)
+		if err != nil {
+			return err
+		}
+		if !s.multiple {
+			break
+		}
+	}
+
+	if !matched {
+		return s.noSuchOptionError(method, criteria, value)
+	}
+
+	return nil
+}
+
+func (s *Select) setSelected(method string, option Element, selected bool) error {
+	resp, err := option.Selected()
+	if err != nil {
+		return err
+	}
+	if resp.Selected == selected {
+		return nil
+	}
+
+	if selected {
+		enabled, err := option.Enabled()
+		if err != nil {
+			return err
+		}
+		if !enabled.Enabled {
+			return fmt.Errorf("%s: a disabled option cannot be selected", strings.ToLower(method))
+		}
+	}
+
+	_, err = option.Click()
+	return err
+}
+
+func (s *Select) noSuchOptionError(method string, criteria string, value interface{}) error {
+	return newNoSuchElementError(method, "", by{t: "option " + criteria, value: value})
+}
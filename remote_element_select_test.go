@@ -0,0 +1,159 @@
+package goselenium
+
+import (
+	"testing"
+)
+
+func setUpSelect(tag string, multiple string) (*seleniumWebDriver, *routedAPIService) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/element/sel/name":               `{"state": "success", "value": "` + tag + `"}`,
+			"/element/sel/attribute/multiple": `{"state": "success", "value": ` + multiple + `}`,
+			"/element/sel/elements": `{
+				"state": "success",
+				"value": [
+					{"element-6066-11e4-a52e-4f735466cecf": "o1"},
+					{"element-6066-11e4-a52e-4f735466cecf": "o2"},
+					{"element-6066-11e4-a52e-4f735466cecf": "o3"}
+				]
+			}`,
+			"/element/o1/text":            `{"state": "success", "value": "One"}`,
+			"/element/o2/text":            `{"state": "success", "value": " Two "}`,
+			"/element/o3/text":            `{"state": "success", "value": "Two"}`,
+			"/element/o1/attribute/value": `{"state": "success", "value": "1"}`,
+			"/element/o2/attribute/value": `{"state": "success", "value": "2"}`,
+			"/element/o3/attribute/value": `{"state": "success", "value": "3"}`,
+			"/element/o1/selected":        `{"state": "success", "value": true}`,
+			"/element/o2/selected":        `{"state": "success", "value": false}`,
+			"/element/o3/selected":        `{"state": "success", "value": false}`,
+			"/enabled":                    `{"state": "success", "value": true}`,
+			"/click":                      `{"state": "success"}`,
+		},
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+	return d, api
+}
+
+func clickedElements(api *routedAPIService) []string {
+	var clicked []string
+	for _, r := range api.requests {
+		if len(r) > 6 && r[len(r)-6:] == "/click" {
+			clicked = append(clicked, r[len(r)-8:len(r)-6])
+		}
+	}
+	return clicked
+}
+
+func Test_Select_NonSelectElementResultsInError(t *testing.T) {
+	d, _ := setUpSelect("div", "null")
+
+	_, err := NewSelect(newSeleniumElement("sel", d))
+	if err == nil || !IsUnexpectedTagNameError(err) {
+		t.Errorf("An error was not returned or was not of the UnexpectedTagNameError type")
+	}
+}
+
+func Test_Select_MultipleAttributeIsDetected(t *testing.T) {
+	d, _ := setUpSelect("select", "null")
+	s, err := NewSelect(newSeleniumElement("sel", d))
+	if err != nil || s.IsMultiple() {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	d, _ = setUpSelect("SELECT", `"true"`)
+	s, err = NewSelect(newSeleniumElement("sel", d))
+	if err != nil || !s.IsMultiple() || s.Element().ID() != "sel" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Select_SelectedOptionsAreReturned(t *testing.T) {
+	d, _ := setUpSelect("select", "null")
+	s, _ := NewSelect(newSeleniumElement("sel", d))
+
+	selected, err := s.SelectedOptions()
+	if err != nil || len(selected) != 1 || selected[0].ID() != "o1" {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	first, err := s.FirstSelectedOption()
+	if err != nil || first.ID() != "o1" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Select_SingleSelectOnlySelectsTheFirstMatch(t *testing.T) {
+	d, api := setUpSelect("select", "null")
+	s, _ := NewSelect(newSeleniumElement("sel", d))
+
+	err := s.SelectByVisibleText("Two")
+	clicked := clickedElements(api)
+	if err != nil || len(clicked) != 1 || clicked[0] != "o2" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Select_MultipleSelectSelectsEveryMatch(t *testing.T) {
+	d, api := setUpSelect("select", `"true"`)
+	s, _ := NewSelect(newSeleniumElement("sel", d))
+
+	err := s.SelectByVisibleText("Two")
+	clicked := clickedElements(api)
+	if err != nil || len(clicked) != 2 || clicked[0] != "o2" || clicked[1] != "o3" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Select_AlreadySelectedOptionsAreNotClicked(t *testing.T) {
+	d, api := setUpSelect("select", "null")
+	s, _ := NewSelect(newSeleniumElement("sel", d))
+
+	err := s.SelectByValue("1")
+	if err != nil || len(clickedElements(api)) != 0 {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Select_SelectByIndexSelectsTheCorrectOption(t *testing.T) {
+	d, api := setUpSelect("select", "null")
+	s, _ := NewSelect(newSeleniumElement("sel", d))
+
+	err := s.SelectByIndex(2)
+	clicked := clickedElements(api)
+	if err != nil || len(clicked) != 1 || clicked[0] != "o3" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_Select_MissingOptionResultsInNoSuchElementError(t *testing.T) {
+	d, _ := setUpSelect("select", "null")
+	s, _ := NewSelect(newSeleniumElement("sel", d))
+
+	err := s.SelectByValue("4")
+	comErr, ok := err.(CommunicationError)
+	if !ok || comErr.Response.State != NoSuchElement {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_Select_DeselectingASingleSelectResultsInError(t *testing.T) {
+	d, _ := setUpSelect("select", "null")
+	s, _ := NewSelect(newSeleniumElement("sel", d))
+
+	if s.DeselectByIndex(0) == nil || s.DeselectAll() == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_Select_DeselectAllClicksSelectedOptions(t *testing.T) {
+	d, api := setUpSelect("select", `"true"`)
+	s, _ := NewSelect(newSeleniumElement("sel", d))
+
+	err := s.DeselectAll()
+	clicked := clickedElements(api)
+	if err != nil || len(clicked) != 1 || clicked[0] != "o1" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
@@ -0,0 +1,159 @@
+package goselenium
+
+import "fmt"
+
+// StaleElementHook is called after each attempt to recover a stale element.
+type StaleElementHook func(r StaleElementRecovery)
+
+// StaleElementRecovery describes an attempt to recover a stale element.
+type StaleElementRecovery struct {
+	// Method is the element method that failed with the stale reference.
+	Method string
+
+	// By is the locator that was used to search for the element again.
+	By By
+
+	// OldID is the ID of the element that had become stale.
+	OldID string
+
+	// NewID is the ID of the element that was found. It is empty if the
+	// element could not be found again.
+	NewID string
+
+	// Err is the error that occurred whilst searching for the element again,
+	// or nil if the element was recovered.
+	Err error
+}
+
+// elementLocator is how an element was found, so that it can be searched for
+// again should it become stale. single is set for elements that were found by
+// the driver's FindElement rather than as part of a list.
+type elementLocator struct {
+	by     By
+	root   *seleniumElement
+	index  int
+	single bool
+}
+
+func (s *seleniumWebDriver) EnableStaleElementRecovery(hook StaleElementHook) {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+
+	s.staleRecovery = true
+	s.staleHook = hook
+}
+
+func (s *seleniumWebDriver) DisableStaleElementRecovery() {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+
+	s.staleRecovery = false
+	s.staleHook = nil
+}
+
+func (s *seleniumWebDriver) staleElementRecovery() (bool, StaleElementHook) {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+
+	return s.staleRecovery, s.staleHook
+}
+
+// rememberLocator records how each of the elements were found.
+func rememberLocator(elements []Element, by By, root *seleniumElement) {
+	for i, el := range elements {
+		if se, ok := el.(*seleniumElement); ok {
+			se.mu.Lock()
+			se.locator = &elementLocator{by: by, root: root, index: i}
+			se.mu.Unlock()
+		}
+	}
+}
+
+// withStaleRecovery calls f and, if it fails because the element is stale and
+// recovery is enabled, searches for the element again and retries f once.
+func withStaleRecovery[T any](s *seleniumElement, method string, f func() (T, error)) (T, error) {
+	result, err := f()
+	if err == nil || !isStaleElementError(err) || s.wd == nil {
+		return result, err
+	}
+
+	enabled, hook := s.wd.staleElementRecovery()
+	s.mu.RLock()
+	locator := s.locator
+	s.mu.RUnlock()
+	if !enabled || locator == nil {
+		return result, err
+	}
+
+	oldID := s.ID()
+	relocateErr := s.relocate(method)
+	if hook != nil {
+		recovery := StaleElementRecovery{Method: method, By: locator.by, OldID: oldID, Err: relocateErr}
+		if relocateErr == nil {
+			recovery.NewID = s.ID()
+		}
+		hook(recovery)
+	}
+	if relocateErr != nil {
+		return result, err
+	}
+
+	return f()
+}
+
+// relocate searches for the element again using the locator that originally
+// found it. If the element's parent has also become stale, it is recovered
+// first.
+func (s *seleniumElement) relocate(method string) error {
+	s.mu.RLock()
+	locator := s.locator
+	s.mu.RUnlock()
+
+	if locator.single {
+		el, err := s.wd.FindElement(locator.by)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		s.id = el.ID()
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	elements, err := s.wd.findElements(locator.by, locator.root, method)
+	if isStaleElementError(err) && locator.root != nil && locator.root.hasLocator() {
+		err = locator.root.relocate(method)
+		if err == nil {
+			elements, err = s.wd.findElements(locator.by, locator.root, method)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if locator.index >= len(elements) {
+		url := fmt.Sprintf("%s/session/%s/elements", s.wd.seleniumURL, s.wd.sessionID)
+		return newNoSuchElementError(method, url, locator.by)
+	}
+
+	s.mu.Lock()
+	s.id = elements[locator.index].ID()
+	s.mu.Unlock()
+
+	return nil
+}
+
+// isStaleElementError checks whether an error was returned because an element
+// is no longer attached to the page.
+func isStaleElementError(err error) bool {
+	comErr, ok := err.(CommunicationError)
+	return ok && comErr.Response != nil && comErr.Response.State == StaleElementReference
+}
+
+func (s *seleniumElement) hasLocator() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.locator != nil
+}
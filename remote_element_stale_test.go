@@ -0,0 +1,131 @@
+package goselenium
+
+import "testing"
+
+func setUpStaleDriver() (*seleniumWebDriver, *routedAPIService) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/element":          `{"state": "success", "value": {"element": "old"}}`,
+			"/element/new/text": `{"state": "success", "value": "recovered"}`,
+		},
+		errors: map[string]error{
+			"/element/old/text": &requestError{State: StaleElementReference},
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	return d, api
+}
+
+func Test_ElementStale_ErrorIsReturnedWhenRecoveryIsDisabled(t *testing.T) {
+	d, _ := setUpStaleDriver()
+
+	el, err := d.FindElement(ByCSSSelector("#name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = el.Text()
+	if !isStaleElementError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_ElementStale_ElementIsFoundAgainAndCommandRetried(t *testing.T) {
+	d, api := setUpStaleDriver()
+
+	var recoveries []StaleElementRecovery
+	d.EnableStaleElementRecovery(func(r StaleElementRecovery) {
+		recoveries = append(recoveries, r)
+	})
+
+	el, err := d.FindElement(ByCSSSelector("#name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api.routes["/element"] = `{"state": "success", "value": {"element": "new"}}`
+
+	resp, err := el.Text()
+	if err != nil || resp.Text != "recovered" || el.ID() != "new" {
+		t.Fatalf(correctResponseErrorText)
+	}
+	if len(recoveries) != 1 || recoveries[0].OldID != "old" || recoveries[0].NewID != "new" ||
+		recoveries[0].Method != "Text" || recoveries[0].Err != nil {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ElementStale_OriginalErrorIsReturnedWhenElementCannotBeFound(t *testing.T) {
+	d, api := setUpStaleDriver()
+
+	var recoveries []StaleElementRecovery
+	d.EnableStaleElementRecovery(func(r StaleElementRecovery) {
+		recoveries = append(recoveries, r)
+	})
+
+	el, err := d.FindElement(ByCSSSelector("#name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api.errors["/session/12345/element"] = &requestError{State: NoSuchElement}
+
+	_, err = el.Text()
+	if !isStaleElementError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+	if len(recoveries) != 1 || recoveries[0].Err == nil || recoveries[0].NewID != "" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ElementStale_DisablingRecoveryReturnsError(t *testing.T) {
+	d, api := setUpStaleDriver()
+	d.EnableStaleElementRecovery(nil)
+	d.DisableStaleElementRecovery()
+
+	el, err := d.FindElement(ByCSSSelector("#name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api.routes["/element"] = `{"state": "success", "value": {"element": "new"}}`
+
+	_, err = el.Text()
+	if !isStaleElementError(err) || el.ID() != "old" {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_ElementStale_StaleParentIsRecoveredFirst(t *testing.T) {
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/element":                     `{"state": "success", "value": {"element": "parent-old"}}`,
+			"/element/parent-old/elements": `{"state": "success", "value": [{"element": "child-old"}]}`,
+			"/element/child-new/text":      `{"state": "success", "value": "recovered"}`,
+		},
+		errors: map[string]error{
+			"/element/child-old/text": &requestError{State: StaleElementReference},
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+	d.EnableStaleElementRecovery(nil)
+
+	parent, err := d.FindElement(ByCSSSelector("form"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	children, err := parent.FindElements(ByCSSSelector("input"))
+	if err != nil || len(children) != 1 {
+		t.Fatal(err)
+	}
+
+	api.errors["/element/parent-old/elements"] = &requestError{State: StaleElementReference}
+	api.routes["/element"] = `{"state": "success", "value": {"element": "parent-new"}}`
+	api.routes["/element/parent-new/elements"] = `{"state": "success", "value": [{"element": "child-new"}]}`
+
+	resp, err := children[0].Text()
+	if err != nil || resp.Text != "recovered" || parent.ID() != "parent-new" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
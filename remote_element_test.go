@@ -2,6 +2,7 @@ package goselenium
 
 import (
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -552,3 +553,76 @@ func Test_ElementSendKeys_CorrectResponseIsReturned(t *testing.T) {
 		t.Errorf(correctResponseErrorText)
 	}
 }
+
+/*
+	FINDELEMENT TESTS
+*/
+func Test_ElementFindElement_ByIndexResultsInArgumentError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &testableAPIService{})
+	d.sessionID = "12345"
+
+	el := newSeleniumElement("0", d)
+	_, err := el.FindElement(ByIndex(1))
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_ElementFindElement_SearchIsScopedToTheElement(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": [{"element-6066-11e4-a52e-4f735466cecf": "1"}]
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	el := newSeleniumElement("0", d)
+	child, err := el.FindElement(ByCSSSelector("li"))
+	if err != nil || child.ID() != "1" || !strings.HasSuffix(api.lastURL, "/element/0/elements") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ElementFindElement_NoSuchElementIsReturnedWhenNothingMatches(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": []
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	el := newSeleniumElement("0", d)
+	_, err := el.FindElement(ByCSSSelector("li"))
+	comErr, ok := err.(CommunicationError)
+	if !ok || comErr.Response.State != NoSuchElement {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_ElementFindElements_ClientSideLocatorsReceiveTheElement(t *testing.T) {
+	api := &testableAPIService{
+		jsonToReturn: `{
+			"state": "success",
+			"value": [{"element-6066-11e4-a52e-4f735466cecf": "1"}, {"element-6066-11e4-a52e-4f735466cecf": "2"}]
+		}`,
+		errorToReturn: nil,
+	}
+
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	el := newSeleniumElement("0", d)
+	children, err := el.FindElements(ByText("Item"))
+	if err != nil || len(children) != 2 ||
+		!strings.Contains(api.lastBody, `,{"ELEMENT":"0","element-6066-11e4-a52e-4f735466cecf":"0"},"text",`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
@@ -0,0 +1,135 @@
+package goselenium
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// ElementUploadResponse is the response returned from calling the Upload
+// method. Paths are the paths of the files on the machine running the
+// browser.
+type ElementUploadResponse struct {
+	State string
+	Paths []string
+}
+
+func (s *seleniumElement) Upload(paths ...string) (*ElementUploadResponse, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("upload: at least one path must be provided")
+	}
+
+	localPaths := make([]string, len(paths))
+	for i, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+		localPaths[i] = abs
+	}
+
+	remotePaths := make([]string, len(localPaths))
+	for i, p := range localPaths {
+		remote, err := s.wd.uploadFile(p)
+		if err != nil {
+			if !isUnknownCommand(err) {
+				return nil, err
+			}
+
+			// The remote end does not support uploading files, which means
+			// that it is a driver running on this machine and can therefore
+			// read the files directly.
+			remotePaths = localPaths
+			break
+		}
+		remotePaths[i] = remote
+	}
+
+	resp, err := s.SendKeys(strings.Join(remotePaths, "\n"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ElementUploadResponse{State: resp.State, Paths: remotePaths}, nil
+}
+
+// uploadFile zips a file and uploads it to the remote end, returning the path
+// that the file was saved to. The Selenium 4 endpoint is tried first, followed
+// by the legacy endpoint used by older grids.
+func (s *seleniumWebDriver) uploadFile(path string) (string, error) {
+	encoded, err := zipFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	b := map[string]string{
+		"file": encoded,
+	}
+	body, err := json.Marshal(b)
+	if err != nil {
+		return "", newMarshallingError(err, "Upload", path)
+	}
+
+	endpoints := []string{"se/file", "file"}
+	for i, e := range endpoints {
+		url := fmt.Sprintf("%s/session/%s/%s", s.seleniumURL, s.sessionID, e)
+
+		resp, err := s.valueRequest(&request{
+			url:           url,
+			method:        "POST",
+			body:          bytes.NewReader(body),
+			callingMethod: "Upload",
+		})
+		if err != nil {
+			if isUnknownCommand(err) && i < len(endpoints)-1 {
+				continue
+			}
+			return "", err
+		}
+
+		return resp.Value, nil
+	}
+
+	return "", nil
+}
+
+func zipFile(path string) (string, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create(filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(contents)
+	if err != nil {
+		return "", err
+	}
+	err = w.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// isUnknownCommand checks whether an error was returned because the remote
+// end does not support the command that was sent.
+func isUnknownCommand(err error) bool {
+	comErr, ok := err.(CommunicationError)
+	if !ok || comErr.Response == nil {
+		return false
+	}
+
+	return comErr.Response.State == UnknownCommand || comErr.Response.State == UnknownMethod
+}
@@ -0,0 +1,142 @@
+package goselenium
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func createUploadFile(t *testing.T, contents string) (string, func()) {
+	dir, err := ioutil.TempDir("", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "invoice.txt")
+	err = ioutil.WriteFile(path, []byte(contents), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func Test_ElementUpload_NoPathsResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &routedAPIService{})
+	d.sessionID = "12345"
+
+	_, err := newSeleniumElement("0", d).Upload()
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
+
+func Test_ElementUpload_FileIsZippedAndUploaded(t *testing.T) {
+	path, cleanUp := createUploadFile(t, "contents")
+	defer cleanUp()
+
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/se/file": `{"state": "success", "value": "/tmp/remote/invoice.txt"}`,
+			"/value":   `{"state": "success"}`,
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := newSeleniumElement("0", d).Upload(path, path)
+	if err != nil || len(resp.Paths) != 2 || resp.Paths[0] != "/tmp/remote/invoice.txt" {
+		t.Fatalf(correctResponseErrorText)
+	}
+
+	var upload map[string]string
+	json.Unmarshal([]byte(api.bodies[0]), &upload)
+	zipped, _ := base64.StdEncoding.DecodeString(upload["file"])
+	r, err := zip.NewReader(bytes.NewReader(zipped), int64(len(zipped)))
+	if err != nil || len(r.File) != 1 || r.File[0].Name != "invoice.txt" {
+		t.Errorf(correctResponseErrorText)
+	}
+
+	keys := api.bodies[len(api.bodies)-1]
+	if !strings.Contains(strings.Replace(keys, `","`, "", -1), `/tmp/remote/invoice.txt\n/tmp/remote/invoice.txt`) {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ElementUpload_LegacyEndpointIsUsedAsAFallback(t *testing.T) {
+	path, cleanUp := createUploadFile(t, "contents")
+	defer cleanUp()
+
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/file":  `{"state": "success", "value": "/tmp/legacy/invoice.txt"}`,
+			"/value": `{"state": "success"}`,
+		},
+		errors: map[string]error{
+			"/se/file": &requestError{State: UnknownCommand},
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := newSeleniumElement("0", d).Upload(path)
+	if err != nil || resp.Paths[0] != "/tmp/legacy/invoice.txt" {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ElementUpload_LocalPathsAreSentWhenUploadIsUnsupported(t *testing.T) {
+	path, cleanUp := createUploadFile(t, "contents")
+	defer cleanUp()
+
+	api := &routedAPIService{
+		routes: map[string]string{
+			"/value": `{"state": "success"}`,
+		},
+		errors: map[string]error{
+			"/file": &requestError{State: UnknownCommand},
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	resp, err := newSeleniumElement("0", d).Upload(path)
+	if err != nil || resp.Paths[0] != path || !strings.HasSuffix(api.requests[len(api.requests)-1], "/element/0/value") {
+		t.Errorf(correctResponseErrorText)
+	}
+}
+
+func Test_ElementUpload_OtherErrorsAreReturned(t *testing.T) {
+	path, cleanUp := createUploadFile(t, "contents")
+	defer cleanUp()
+
+	api := &routedAPIService{
+		errors: map[string]error{
+			"/se/file": errors.New("An error :<"),
+		},
+	}
+	d := setUpDriver(setUpDefaultCaps(), api)
+	d.sessionID = "12345"
+
+	_, err := newSeleniumElement("0", d).Upload(path)
+	if err == nil || !IsCommunicationError(err) {
+		t.Errorf(apiCommunicationErrorText)
+	}
+}
+
+func Test_ElementUpload_MissingFileResultsInError(t *testing.T) {
+	d := setUpDriver(setUpDefaultCaps(), &routedAPIService{})
+	d.sessionID = "12345"
+
+	_, err := newSeleniumElement("0", d).Upload("/this/file/does/not/exist")
+	if err == nil {
+		t.Errorf(argumentErrorText)
+	}
+}
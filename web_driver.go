@@ -1,6 +1,9 @@
 package goselenium
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // Keyboard keys converted from the ASCII code.
 const (
@@ -52,37 +55,42 @@ const (
 	ZenkakuHankakuKey = string('\uE040')
 )
 
+// NullKey releases every modifier key that has been pressed whilst sending
+// keys. It is the same code point as UnidentifiedKey.
+const NullKey = string('\uE000')
+
 // Error codes that are returned from Selenium. Infer that the
 // type of the error returned is CommunicationError, then do a comparison
 // on the err.Response.State field to one of the below constants.
 const (
-	ElementNotSelectable   = "element not selectable"
-	ElementNotInteractable = "element not interactable"
-	InsecureCertificate    = "insecure certificate"
-	InvalidArgument        = "invalid argument"
-	InvalidCookieDomain    = "invalid cookie domain"
-	InvalidCoordinates     = "invalid coordinates"
-	InvalidElementState    = "invalid element state"
-	InvalidSelector        = "invalid selector"
-	InvalidSessionID       = "invalid session id"
-	JavascriptError        = "javascript error"
-	MoveTargetOutOfBounds  = "move target out of bounds"
-	NoSuchAlert            = "no such alert"
-	NoSuchCookie           = "no such cookie"
-	NoSuchElement          = "no such element"
-	NoSuchFrame            = "no such frame"
-	NoSuchWindow           = "no such window"
-	ScriptTimeout          = "script timeout"
-	SessionNotCreated      = "session not created"
-	StaleElementReference  = "stale element reference"
-	TimeoutError           = "timeout"
-	UnableToSetCookie      = "unable to set cookie"
-	UnableToCaptureScreen  = "unable to capture screen"
-	UnexpectedAlertOpen    = "unexpected alert open"
-	UnknownCommand         = "unknown command"
-	UnknownError           = "unknown error"
-	UnknownMethod          = "unknown method"
-	UnsupportedOperation   = "unsupported operation"
+	ElementClickIntercepted = "element click intercepted"
+	ElementNotSelectable    = "element not selectable"
+	ElementNotInteractable  = "element not interactable"
+	InsecureCertificate     = "insecure certificate"
+	InvalidArgument         = "invalid argument"
+	InvalidCookieDomain     = "invalid cookie domain"
+	InvalidCoordinates      = "invalid coordinates"
+	InvalidElementState     = "invalid element state"
+	InvalidSelector         = "invalid selector"
+	InvalidSessionID        = "invalid session id"
+	JavascriptError         = "javascript error"
+	MoveTargetOutOfBounds   = "move target out of bounds"
+	NoSuchAlert             = "no such alert"
+	NoSuchCookie            = "no such cookie"
+	NoSuchElement           = "no such element"
+	NoSuchFrame             = "no such frame"
+	NoSuchWindow            = "no such window"
+	ScriptTimeout           = "script timeout"
+	SessionNotCreated       = "session not created"
+	StaleElementReference   = "stale element reference"
+	TimeoutError            = "timeout"
+	UnableToSetCookie       = "unable to set cookie"
+	UnableToCaptureScreen   = "unable to capture screen"
+	UnexpectedAlertOpen     = "unexpected alert open"
+	UnknownCommand          = "unknown command"
+	UnknownError            = "unknown error"
+	UnknownMethod           = "unknown method"
+	UnsupportedOperation    = "unsupported operation"
 )
 
 // WebDriver is an interface which adheres to the W3C specification
@@ -153,15 +161,56 @@ type WebDriver interface {
 	// handle.
 	SwitchToWindow(handle string) (*SwitchToWindowResponse, error)
 
+	// WithinWindow switches to the window with the handle passed in, calls f
+	// and then switches back to the window which was current beforehand,
+	// even if f returns an error. Switching windows always leaves frames, so
+	// the previous window is restored at its top level browsing context.
+	WithinWindow(handle string, f func() error) error
+
 	// WindowHandles gets all of the window handles for the current session.
 	// To retrieve the currently active window handle, see WindowHandle().
 	WindowHandles() (*WindowHandlesResponse, error)
 
 	// SwitchToFrame switches to a frame determined by the "by" parameter.
-	// You can use ByIndex to find the frame to switch to. Any other
-	// By implementation will yield an InvalidByParameter error.
+	// ByIndex switches to the frame at that index within the current
+	// browsing context, ByElement switches to a frame element that has
+	// already been found and ByFrameName switches to a frame by its name or
+	// id attribute. Any other By implementation is used to find the frame
+	// element first.
 	SwitchToFrame(by By) (*SwitchToFrameResponse, error)
 
+	// SwitchToDefaultContent switches back to the top level browsing context
+	// of the current window, leaving any frames that have been switched to.
+	SwitchToDefaultContent() (*SwitchToFrameResponse, error)
+
+	// WithinFrame switches to the frame determined by the "by" parameter (see
+	// SwitchToFrame), calls f and then switches back to the frame that was
+	// current beforehand, even if f returns an error or switches to other
+	// frames. If f leaves a different frame as the current browsing context,
+	// the previous frame is re-entered by its path. That is not possible if
+	// the previous frame was switched to by its element (rather than its
+	// index); in that case the parent frame is switched to, or the top level
+	// browsing context if f returns an error.
+	WithinFrame(by By, f func() error) error
+
+	// SwitchToFramePath switches to the top level browsing context and then
+	// to each frame index within the path in turn, such as the Path of a
+	// Frame returned by FrameTree.
+	SwitchToFramePath(path FramePath) (*SwitchToFrameResponse, error)
+
+	// FrameTree retrieves every frame within the page, including frames
+	// nested within other frames. The root of the tree is the top level
+	// browsing context, which is the current browsing context afterwards.
+	FrameTree() (*Frame, error)
+
+	// FindElementInFrames searches the top level browsing context and then
+	// every frame within it (depth first) for an element. When the element
+	// is found, the frame containing it is left as the current browsing
+	// context so that the element can be interacted with; the path of the
+	// frame is returned so that it can be switched back to later. If it is
+	// not found, a 'no such element' CommunicationError is returned.
+	FindElementInFrames(by By) (*FrameElement, error)
+
 	// SwitchToParentFrame switches to the parent of the current top level
 	// browsing context.
 	SwitchToParentFrame() (*SwitchToParentFrameResponse, error)
@@ -177,6 +226,51 @@ type WebDriver interface {
 	// Maximize increases the current browser window to its maximum size.
 	MaximizeWindow() (*MaximizeWindowResponse, error)
 
+	// NewWindow creates a new browser window or tab (TabWindowType or
+	// BrowserWindowType) without switching to it. The handle of the new
+	// window is returned so that SwitchToWindow can be called with it.
+	NewWindow(windowType string) (*NewWindowResponse, error)
+
+	// WindowRect retrieves the position and size of the current browser
+	// window. Drivers which do not support the W3C rect endpoint fall back
+	// to the legacy size and position endpoints.
+	WindowRect() (*WindowRectResponse, error)
+
+	// SetWindowRect moves and/or resizes the current browser window. Any
+	// option that is nil is left unchanged. Drivers which do not support
+	// the W3C rect endpoint fall back to the legacy size and position
+	// endpoints.
+	SetWindowRect(opts *WindowRectOptions) (*SetWindowRectResponse, error)
+
+	// MinimizeWindow minimizes (iconifies) the current browser window.
+	// Drivers which predate the W3C specification have no equivalent
+	// endpoint, so an UnsupportedCommandError is returned for them.
+	MinimizeWindow() (*MinimizeWindowResponse, error)
+
+	// FullscreenWindow makes the current browser window full screen.
+	// Drivers which predate the W3C specification have no equivalent
+	// endpoint, so an UnsupportedCommandError is returned for them.
+	FullscreenWindow() (*FullscreenWindowResponse, error)
+
+	// CurrentWindow retrieves the handle, position and size of the current
+	// browser window. The Window returned can be used to switch back to,
+	// close or re-measure the window later on.
+	CurrentWindow() (*Window, error)
+
+	// WaitForNewWindow calls action (i.e. clicking a link which opens in a
+	// new tab) and then waits until a window which did not exist beforehand
+	// has been opened, switches to it and returns its handle.
+	//
+	// If match is not nil, it is checked whilst each new window is switched
+	// to and only a window that satisfies it is accepted. It can be any
+	// Condition, such as UntilURLContains("/invoice"), UntilTitleIs("Invoice")
+	// or an Until func; errors from checking it mean that the window does not
+	// match yet. A condition created with invalid arguments is rejected before
+	// action is called. If the context is done before a window is accepted,
+	// the window which was current is switched back to and a
+	// WaitTimeoutError is returned.
+	WaitForNewWindow(ctx context.Context, action func() error, match Condition) (string, error)
+
 	/*
 		ELEMENT METHODS
 	*/
@@ -190,6 +284,28 @@ type WebDriver interface {
 	// one result.
 	FindElements(by By) ([]Element, error)
 
+	// EnableStaleElementRecovery makes elements remember the By (and parent
+	// element) that found them. If a command then fails with a 'stale element
+	// reference' error, the element is searched for again and the command is
+	// retried once. The hook is called after every recovery attempt and may be
+	// nil.
+	EnableStaleElementRecovery(hook StaleElementHook)
+
+	// DisableStaleElementRecovery disables the recovery of stale elements
+	// enabled by EnableStaleElementRecovery.
+	DisableStaleElementRecovery()
+
+	// EnableRobustClick changes Element.Click so that the element is scrolled
+	// into the centre of the viewport and is waited on until it has stopped
+	// moving and is not covered by another element before it is clicked. If
+	// the click is intercepted, it is retried. If the element is still covered
+	// after every attempt, an ElementClickInterceptedError describing the
+	// covering element is returned. If opts is nil, the defaults are used.
+	EnableRobustClick(opts *RobustClickOptions)
+
+	// DisableRobustClick restores the default behaviour of Element.Click.
+	DisableRobustClick()
+
 	/*
 		DOCUMENT HANDLING METHODS
 	*/
@@ -198,8 +314,15 @@ type WebDriver interface {
 	PageSource() (*PageSourceResponse, error)
 
 	// ExecuteScript executes a Javascript script on the currently active
-	// page.
-	ExecuteScript(script string) (*ExecuteScriptResponse, error)
+	// page. The arguments are available to the script through the
+	// 'arguments' array and can be any value that can be marshalled to JSON.
+	// Elements (including those within slices and maps) are passed to the
+	// script as DOM elements.
+	//
+	// The value returned by the script can be decoded with the Decode method
+	// of the response; elements that are returned can be retrieved with its
+	// Element, Elements or Result methods.
+	ExecuteScript(script string, args ...interface{}) (*ExecuteScriptResponse, error)
 
 	// ExecuteScriptAsync executes a Javascript script asynchronously on the
 	// currently active page. If you do not have experience with this call,
@@ -209,19 +332,20 @@ type WebDriver interface {
 	// your code asynchronously and if it completes, will call the callback.
 	//
 	// Selenium helpfully provides a callback function which is passed in
-	// to the 'arguments' array that you can access within your script. The
-	// callback function is always the LAST element of the array. You can
+	// to the 'arguments' array that you can access within your script, after
+	// any arguments that you pass in. The callback function is always the
+	// LAST element of the array. You can
 	// access it like the below:
 	//		var callback = arguments[arguments.length - 1];
 	// The callback function also accepts one argument as a parameter, this
-	// can be anything and will be assigned to the Response property of
-	// ExecuteScriptResponse.
+	// can be anything and will be returned in the same way as the result of
+	// ExecuteScript.
 	//
 	// An example:
 	//		var callback = arguments[arguments.length - 1];
 	//		doLongWindedTask();
 	//		callback();
-	ExecuteScriptAsync(script string) (*ExecuteScriptResponse, error)
+	ExecuteScriptAsync(script string, args ...interface{}) (*ExecuteScriptResponse, error)
 
 	/*
 		COOKIE METHODS
@@ -268,6 +392,24 @@ type WebDriver interface {
 	// code 'unsupported operation' will be returned.
 	SendAlertText(text string) (*SendAlertTextResponse, error)
 
+	/*
+		ACTION METHODS
+	*/
+
+	// PerformActions performs a set of actions built with NewActions, which
+	// allows for interactions such as hovering, dragging and dropping and
+	// holding down modifier keys.
+	PerformActions(a *Actions) (*PerformActionsResponse, error)
+
+	// ReleaseActions releases any keys or pointer buttons that are still
+	// pressed as a result of PerformActions.
+	ReleaseActions() (*ReleaseActionsResponse, error)
+
+	// PrimaryModifierKey returns the modifier key used for shortcuts (such as
+	// copy and paste) on the platform of the current session; MetaKey on
+	// macOS and ControlKey everywhere else.
+	PrimaryModifierKey() string
+
 	/*
 		SCREEN CAPTURE METHODS
 	*/
@@ -276,18 +418,34 @@ type WebDriver interface {
 	// browsing context. The image returned will be a PNG image.
 	Screenshot() (*ScreenshotResponse, error)
 
+	// Print renders the current page as a PDF. If opts is nil, the defaults
+	// of the remote end are used.
+	Print(opts *PrintOptions) (*PrintResponse, error)
+
 	/*
 		HELPER METHODS
 	*/
 
 	// Wait repeats an action until the action yields a valid result or
 	// until the timeout is reached. If the timeout is reached without
-	// the until function returning a satisfactory result, this method
-	// will return false.
+	// the condition being satisfied, this method will return false. Any
+	// Condition can be passed, including Until functions and the
+	// conditions such as UntilElementVisible; an error returned by a
+	// condition is treated as it not being satisfied yet.
 	//
 	// time.Sleep will be called with the sleep parameter after every
-	// u iteration.
-	Wait(u Until, timeout time.Duration, sleep time.Duration) bool
+	// iteration. See WaitContext for a wait which reports why it failed.
+	Wait(c Condition, timeout time.Duration, sleep time.Duration) bool
+
+	// WaitContext checks a condition every interval (or DefaultWaitInterval
+	// if the interval is zero) until it is satisfied or until the context is
+	// done. If the context is done first, a WaitTimeoutError describing the
+	// condition, the number of attempts and the last error returned whilst
+	// checking it is returned.
+	//
+	// Any Until function can be passed as the condition, or see WaitForValue
+	// and WaitForElement to wait for a value.
+	WaitContext(ctx context.Context, c Condition, interval time.Duration) error
 }
 
 // Element is an interface which specifies what all WebDriver elements
@@ -331,11 +489,35 @@ type Element interface {
 	// automatically wait until the page title has changed.
 	Click() (*ElementClickResponse, error)
 
+	// ScrollIntoView scrolls the element into the centre of the viewport.
+	ScrollIntoView() (*ElementScrollIntoViewResponse, error)
+
 	// Clear clears the currently selected element according to the specification.
 	Clear() (*ElementClearResponse, error)
 
 	// SendKeys sends a set of keystrokes to the currently selected element.
 	SendKeys(keys string) (*ElementSendKeysResponse, error)
+
+	// SendKeysWithDelay sends a set of keystrokes to the currently selected
+	// element one at a time, waiting for the delay in between each one. Chords
+	// (see Chord) are sent as a single keystroke.
+	SendKeysWithDelay(keys string, delay time.Duration) (*ElementSendKeysResponse, error)
+
+	// FindElement finds a descendant of the current element via a By
+	// implementation. Attempting to find via index will result in an argument
+	// error being thrown.
+	FindElement(by By) (Element, error)
+
+	// FindElements works the same way as FindElement but can return more than
+	// one result.
+	FindElements(by By) ([]Element, error)
+
+	// Upload sets the files of a file input element. When the browser is
+	// running on a remote machine (i.e. a Selenium grid), each file is first
+	// uploaded to that machine. If the remote end does not support uploading
+	// files, the paths are assumed to be accessible to the browser and are
+	// sent as they are.
+	Upload(paths ...string) (*ElementUploadResponse, error)
 }
 
 // Timeout is an interface which specifies what all timeout requests must follow.